@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/hoyle1974/grapevine/common"
 	"github.com/hoyle1974/grapevine/microservice"
@@ -65,6 +68,10 @@ func (s *server) Auth(ctx context.Context, in *pb.AuthRequest) (*pb.AuthResponse
 	if err != nil {
 		return &pb.AuthResponse{Error: microservice.ErrToProto(err)}, err
 	}
+	session, err := services.CreateSession(s.appCtx, accountId)
+	if err != nil {
+		return &pb.AuthResponse{Error: microservice.ErrToProto(err)}, err
+	}
 
 	return &pb.AuthResponse{
 		Message:   "Hello " + in.GetUsername(),
@@ -72,12 +79,66 @@ func (s *server) Auth(ctx context.Context, in *pb.AuthRequest) (*pb.AuthResponse
 		Blocked:   common.AccountIdsToStrings(blockedIds),
 		Follows:   common.ContactsToPB(follows),
 		Following: common.ContactsToPB(following),
+		Session:   session,
 	}, nil
 
 }
 
+// Limits on how many accounts a caller can resolve, at once and in a minute.
+const (
+	maxResolve          = 100
+	maxResolvePerMinute = 1000
+)
+
+// contactServer tells clients where accounts they may invite last logged in
+// from, so they can be reached without searching for them first.
+type contactServer struct {
+	pb.UnimplementedContactServiceServer
+	appCtx services.AppCtx
+
+	lock     sync.Mutex
+	window   time.Time
+	resolved map[common.AccountId]int // In the current minute
+}
+
+// allow counts n more accounts resolved by caller, unless that is more than
+// it may resolve this minute.
+func (s *contactServer) allow(caller common.AccountId, n int) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if now := time.Now(); now.Sub(s.window) > time.Minute {
+		s.window = now
+		s.resolved = make(map[common.AccountId]int)
+	}
+	if s.resolved[caller]+n > maxResolvePerMinute {
+		return false
+	}
+	s.resolved[caller] += n
+	return true
+}
+
+func (s *contactServer) ResolveContacts(ctx context.Context, in *pb.ResolveContactsRequest) (*pb.ResolveContactsResponse, error) {
+	caller, err := services.CheckSession(s.appCtx, in.GetSession())
+	if err != nil {
+		return &pb.ResolveContactsResponse{Error: microservice.ErrToProto(err)}, err
+	}
+	if n := len(in.GetAccountIds()); n > maxResolve || !s.allow(caller, n) {
+		err := fmt.Errorf("too many accounts to resolve, at most %d at once and %d a minute", maxResolve, maxResolvePerMinute)
+		return &pb.ResolveContactsResponse{Error: microservice.ErrToProto(err)}, err
+	}
+
+	contacts, err := services.GetInvitableContacts(s.appCtx, caller, common.StringsToAccountIds(in.GetAccountIds()))
+	if err != nil {
+		return &pb.ResolveContactsResponse{Error: microservice.ErrToProto(err)}, err
+	}
+
+	return &pb.ResolveContactsResponse{Contacts: common.ContactsToPB(contacts)}, nil
+}
+
 func register(appCtx services.AppCtx) {
 	pb.RegisterAuthServiceServer(appCtx.Server, &server{appCtx: appCtx})
+	pb.RegisterContactServiceServer(appCtx.Server, &contactServer{appCtx: appCtx})
 }
 
 func main() {
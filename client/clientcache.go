@@ -44,27 +44,35 @@ type grapevineClient struct {
 	httpClient   *http.Client
 }
 
+// ResetExpiry keeps the client for another minute.  It is called with the
+// lock of the cache held.
 func (g *grapevineClient) ResetExpiry() {
+	g.expiry = time.Now().Add(time.Minute)
 }
 
+// GetClient returns the http client.  It leaves the expiry alone, the cache
+// resets that each time it hands the client out.
 func (g *grapevineClient) GetClient() *http.Client {
-	g.ResetExpiry()
 	return g.httpClient
 }
 
+// cleanupConnections closes the clients that haven't been handed out for a
+// minute.  It is called with the lock held.
 func (g *grapevineClientCache) cleanupConnections() {
 	for key, value := range g.clients {
-		if value.expiry.After(time.Now()) {
+		if value.expiry.Before(time.Now()) {
 			delete(g.clients, key)
 			value.roundTripper.Close()
 		}
 	}
 }
 
+// GetClient returns the client for addr, which is kept for another minute,
+// and closes the ones that have been idle for longer.
 func (g *grapevineClientCache) GetClient(addr common.Address) GrapevineClient {
 	g.lock.Lock()
-	defer g.cleanupConnections()
 	defer g.lock.Unlock()
+	defer g.cleanupConnections()
 
 	key := addr.GetURL()
 
@@ -100,6 +108,16 @@ func (g *grapevineClientCache) GetClient(addr common.Address) GrapevineClient {
 	return client
 }
 
+// StatusError is returned by POST when the peer answered with anything but 200,
+// it means the request arrived but was not accepted.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("invalid status code: %d", e.StatusCode)
+}
+
 // Helper functions to make posts
 func (g *grapevineClientCache) POST(addr common.Address, url string, req proto.Message, gresp proto.Message) error {
 	// fmt.Printf("*** POST %s\n", fmt.Sprintf("https://%s%s", addr.GetURL(), url))
@@ -115,7 +133,7 @@ func (g *grapevineClientCache) POST(addr common.Address, url string, req proto.M
 		return err
 	}
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("invalid status code: %d", resp.StatusCode)
+		return &StatusError{StatusCode: resp.StatusCode}
 	}
 
 	b, err = io.ReadAll(resp.Body)
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hoyle1974/grapevine/common"
+)
+
+func TestClientExpiry(t *testing.T) {
+	cache := NewGrapevineClientCache().(*grapevineClientCache)
+	idle := common.NewAddress(net.ParseIP("127.0.0.1"), 1)
+	busy := common.NewAddress(net.ParseIP("127.0.0.1"), 2)
+
+	cache.GetClient(idle)
+	client := cache.GetClient(busy)
+	if _, ok := cache.clients[idle.GetURL()]; !ok {
+		t.Fatal("A client was closed before it expired")
+	}
+
+	// Only the client that wasn't used for a minute is closed
+	cache.clients[idle.GetURL()].expiry = time.Now().Add(-time.Second)
+	cache.clients[busy.GetURL()].expiry = time.Now().Add(time.Second)
+	if cache.GetClient(busy) != client {
+		t.Error("The client in use was replaced")
+	}
+	if _, ok := cache.clients[idle.GetURL()]; ok {
+		t.Error("The idle client wasn't closed")
+	}
+	if cache.clients[busy.GetURL()].expiry.Before(time.Now().Add(time.Minute - time.Second)) {
+		t.Error("Handing out the client didn't keep it for another minute")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"flag"
 	"fmt"
 	"net"
@@ -9,7 +10,6 @@ import (
 	"strconv"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -43,6 +43,7 @@ type Callback struct {
 	sharedData shareddata.SharedData
 	grapevine  grapevine.Grapevine
 	gp         Gameplay
+	playing    chan struct{} // Closed to end the game being played
 }
 
 const gameType = "grapevine.com/game/example/tictactoe/v1"
@@ -50,6 +51,7 @@ const gameType = "grapevine.com/game/example/tictactoe/v1"
 type Gameplay interface {
 	SetPrompt(prompt string)
 	UpdateBoard(b string)
+	WatchData(sd shareddata.SharedData, changes <-chan shareddata.Change)
 }
 
 // Someone is searching for this query
@@ -93,7 +95,6 @@ func (c *Callback) OnSearchResult(id shareddata.SearchId, query string, contact
 	c.sharedData.Create("state", "start", c.sharedData.GetMe(), "default")
 	c.sharedData.Create("board", ".........", c.sharedData.GetMe(), "default")
 	c.sharedData.Create("chat", []string{}, "default", "default")
-	c.sharedData.Create("visibility-group", map[string][]string{"default": []string{"player1", "player2"}}, "system", "default")
 	c.sharedData = c.grapevine.Serve(c.sharedData) // The structure is now live and can be worked with by this client or others
 
 	// Invite your contact to join the structure as player2
@@ -115,6 +116,7 @@ func (c *Callback) OnInvited(sharedDataId shareddata.SharedDataId, me string, co
 
 	if c.sharedData != nil {
 		// Leave any existing share we have, probably not the best choice
+		c.stopPlay()
 		c.grapevine.LeaveShare(c.sharedData)
 		c.sharedData = nil
 	}
@@ -131,8 +133,7 @@ func (c *Callback) OnSharedDataAvailable(sharedData shareddata.SharedData) {
 
 	c.sharedData = sharedData
 
-	go c.play(c.gp)
-
+	c.startPlay()
 }
 
 // Someone accepted our invitation to share the data
@@ -152,7 +153,18 @@ func (c *Callback) OnInviteAccepted(sharedData shareddata.SharedData, contact co
 	//log.Info().Msgf("Player %v has joined", contact.AccountId)
 	c.sharedData.Set("state", "player1")
 
-	go c.play(c.gp)
+	c.startPlay()
+}
+
+// We invite and answer invitations straight away, so never hear of these
+func (c *Callback) OnInviteReceived(invite shareddata.Invitation) {
+}
+
+func (c *Callback) OnInviteDeclined(invite shareddata.Invitation, reason string) {
+}
+
+// The player hosting the game left, only two play so we carry on as the host
+func (c *Callback) OnHostChanged(sharedData shareddata.SharedData, host string, contact common.Contact) {
 }
 
 func getInput() (string, string) {
@@ -244,19 +256,30 @@ func (c *Callback) Click(x int, y int) {
 			return
 		}
 
-		c.sharedData.Set("board", b)
-		if didIWin(c.sharedData.Get("board").(string), piece) {
-			c.sharedData.Set("state", "finished")
-			c.sharedData.ChangeDataOwner("board", "default")
-			c.sharedData.ChangeDataOwner("state", "default")
-			//log.Info().Msgf("You won!")
-		} else {
-			// Other player can move
-			c.sharedData.Set("state", otherPlayer)
-		}
+		// The whole move is seen at once, it still counts if the other player
+		// just couldn't be reached
+		var derr *shareddata.DeliveryError
+		err = c.sharedData.Transaction(func(tx shareddata.Tx) error {
+			if err := tx.Set("board", b); err != nil {
+				return err
+			}
+			if didIWin(b, piece) {
+				tx.Set("state", "finished")
+				tx.ChangeDataOwner("board", "default")
+				tx.ChangeDataOwner("state", "default")
+				//log.Info().Msgf("You won!")
+			} else {
+				// Other player can move
+				tx.Set("state", otherPlayer)
+			}
 
-		c.sharedData.ChangeDataOwner("board", otherPlayer)
-		c.sharedData.ChangeDataOwner("state", otherPlayer)
+			tx.ChangeDataOwner("board", otherPlayer)
+			return tx.ChangeDataOwner("state", otherPlayer)
+		})
+		if err != nil && !errors.As(err, &derr) {
+			//log.Info().Msgf("Error with move: %v", err)
+			return
+		}
 	}
 }
 
@@ -264,33 +287,67 @@ func (c *Callback) Chat(msg string) {
 
 }
 
-func (c *Callback) play(gp Gameplay) {
+// startPlay plays the current share until the game is over or stopPlay is
+// called, it is called with the lock held.
+func (c *Callback) startPlay() {
+	c.stopPlay()
+	c.playing = make(chan struct{})
+	go c.play(c.gp, c.sharedData, c.playing)
+}
+
+// stopPlay ends the game being played, if there is one.  It is called with
+// the lock held.
+func (c *Callback) stopPlay() {
+	if c.playing != nil {
+		close(c.playing)
+		c.playing = nil
+	}
+}
+
+func (c *Callback) play(gp Gameplay, sd shareddata.SharedData, stop <-chan struct{}) {
 	//log := c.ctx.NewCtx("play")
 
 	//log.Info().Msgf("TICTACTOE - PLAY 1")
-	// c.sharedData.OnDataChangeCB(func(key string) {
-	// 	if key == "chat" {
-	// 		//chat := c.sharedData.Get("chat").([]string)
-	// 		//log.Info().Msgf("Chat: " + chat[len(chat)-1])
-	// 	}
-	// })
+	watch := sd.Watch("")
+	defer sd.Unwatch(watch)
+	gp.WatchData(sd, watch)
+
+	changed := make(chan bool, 1)
+	unregister := sd.OnDataChangeCB(func(change shareddata.DataChange) {
+		if change.Key == "board" || change.Key == "state" {
+			select {
+			case changed <- true:
+			default:
+			}
+		}
+	})
+	defer unregister()
 
 	for {
-		time.Sleep(time.Second / 30)
+		gp.UpdateBoard(sd.Get("board").(string))
 
-		gp.UpdateBoard(c.sharedData.Get("board").(string))
-
-		//log.Info().Msgf("TICTACTOE - PLAY 3: State Owner = %v", c.sharedData.GetOwner("state"))
+		//log.Info().Msgf("TICTACTOE - PLAY 3: State Owner = %v", sd.GetOwner("state"))
 		msg := ""
-		if c.sharedData.Get("state").(string) == c.sharedData.GetMe() {
-			msg = fmt.Sprintf("(%v)\nYour turn, make a move or chat:", c.sharedData.GetId())
-		} else if c.sharedData.Get("state") != "finished" {
-			msg = fmt.Sprintf("(%v)\nWaiting for other player, you may chat:", c.sharedData.GetId())
+		over := false
+		if sd.Get("state").(string) == sd.GetMe() {
+			msg = fmt.Sprintf("(%v)\nYour turn, make a move or chat:", sd.GetId())
+		} else if sd.Get("state") != "finished" {
+			msg = fmt.Sprintf("(%v)\nWaiting for other player, you may chat:", sd.GetId())
 		} else {
-			msg = fmt.Sprintf("(%v)\nGame is over, you may still chat:", c.sharedData.GetId())
+			msg = fmt.Sprintf("(%v)\nGame is over, you may still chat:", sd.GetId())
+			over = true
 		}
-		b := c.sharedData.Get("board").(string)
+		b := sd.Get("board").(string)
 		gp.SetPrompt(b + " : " + msg)
+		if over {
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-stop:
+			return
+		}
 
 		/*
 			// Take a turn, blocking on input
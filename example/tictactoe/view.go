@@ -2,9 +2,11 @@ package main
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/hoyle1974/grapevine/grapevine"
+	"github.com/hoyle1974/grapevine/shareddata"
 	"github.com/rivo/tview"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -15,6 +17,7 @@ var grid *tview.Grid
 var gameData *tview.TreeView
 var gameDataRoot *tview.TreeNode
 var mongers *tview.TreeNode
+var share *tview.TreeNode
 var logs *tview.TextView
 
 var b00, b10, b20, b01, b11, b21, b02, b12, b22 *tview.Button
@@ -43,6 +46,42 @@ func (gp *gameplay) UpdateBoard(b string) {
 	b22.SetLabel(fmt.Sprintf("%c", b[8]))
 }
 
+// WatchData shows the keys of sd in the tree as they change, until changes
+// is closed.
+func (gp *gameplay) WatchData(sd shareddata.SharedData, changes <-chan shareddata.Change) {
+	go func() {
+		values := map[string]interface{}{}
+		for change := range changes {
+			switch {
+			case change.Snapshot != nil:
+				values = change.Snapshot
+			case change.Path != "":
+				// Only part of the key changed, read the rest of it again
+				values[change.Key] = sd.Get(change.Key)
+			case change.Kind == shareddata.CreateChange || change.Kind == shareddata.SetChange:
+				values[change.Key] = change.NewValue
+			case change.Kind == shareddata.AppendChange:
+				if a, ok := values[change.Key].([]interface{}); ok {
+					values[change.Key] = append(a, change.NewValue)
+				}
+			case change.Kind == shareddata.DeleteChange:
+				delete(values, change.Key)
+			}
+
+			keys := make([]string, 0, len(values))
+			for key := range values {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			share.ClearChildren()
+			for _, key := range keys {
+				share.AddChild(tview.NewTreeNode(fmt.Sprintf("%v: %v", key, values[key])))
+			}
+			app.Draw()
+		}
+	}()
+}
+
 func setTuiApp(gi GameInput) Gameplay {
 	newPrimitive := func(text string) tview.Primitive {
 		return tview.NewTextView().
@@ -55,6 +94,9 @@ func setTuiApp(gi GameInput) Gameplay {
 	mongers = tview.NewTreeNode("mongers")
 	gameDataRoot.AddChild(mongers)
 
+	share = tview.NewTreeNode("share")
+	gameDataRoot.AddChild(share)
+
 	main := tview.NewTextView().
 		SetDynamicColors(true)
 
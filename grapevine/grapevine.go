@@ -2,6 +2,8 @@ package grapevine
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
 	"sync"
 	"time"
@@ -21,17 +23,33 @@ import (
 type Grapevine interface {
 	Start(ip net.IP) (int, error)
 	Serve(s shareddata.SharedData) shareddata.SharedData
-	JoinShare(s shareddata.SharedData)
+	JoinShare(s shareddata.SharedData) (shareddata.SharedData, error)
 	LeaveShare(s shareddata.SharedData)
 	Invite(s shareddata.SharedData, recipient common.Contact, as string) bool
+	InviteAccount(s shareddata.SharedData, accountId common.AccountId, as string) (bool, error)
+	SendInvite(s shareddata.SharedData, recipient common.Contact, as string, options shareddata.InviteOptions) (shareddata.Invitation, error)
+	RevokeInvite(id shareddata.InviteId) error
+	AcceptInvite(id shareddata.InviteId) (shareddata.SharedData, error)
+	DeclineInvite(id shareddata.InviteId, reason string) error
+	IncomingInvites() []shareddata.Invitation
+	OutgoingInvites() []shareddata.Invitation
+	CreateJoinToken(s shareddata.SharedData, as string, expiry time.Duration) (string, error)
+	JoinWithToken(token string) (shareddata.SharedData, error)
 	Search(key string) shareddata.SearchId
 	GetMe() common.Contact
 	GetMongers() []common.Address
+	SetCodec(codec shareddata.Codec)
+	SetStore(store shareddata.SharedDataStore)
+	Resume() ([]shareddata.SharedData, error)
 
 	CreateAccount(username string, password string) error
 	Login(username string, password string, ip net.IP, port int) (common.AccountId, error)
+	ResolveContacts(accountIds []common.AccountId) ([]common.Contact, error)
 }
 
+var ErrUnknownAccount = errors.New("unknown account")
+var ErrNotLoggedIn = errors.New("not logged in")
+
 type grapevine struct {
 	lock              sync.Mutex
 	ctx               common.CallCtx
@@ -39,8 +57,11 @@ type grapevine struct {
 	listener          GrapevineListener
 	clientCache       client.GrapevineClientCache
 	accountId         common.AccountId
+	session           string // From Login, for calls that need us logged in
 	gossip            gossip.Gossip
 	sharedDataManager shareddata.SharedDataManager
+	codec             shareddata.Codec
+	store             shareddata.SharedDataStore
 }
 
 func NewGrapevine(cb shareddata.ClientCallback, ctx common.CallCtx) Grapevine {
@@ -55,6 +76,33 @@ func (g *grapevine) GetMe() common.Contact {
 	return g.listener.GetMe()
 }
 
+// SetCodec chooses how the values of shared data are sent, it can be called
+// before Start.
+func (g *grapevine) SetCodec(codec shareddata.Codec) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.codec = codec
+	if g.sharedDataManager != nil {
+		g.sharedDataManager.SetCodec(codec)
+	}
+}
+
+// SetStore keeps shared data in store so it survives restarts, it can be
+// called before Start.
+func (g *grapevine) SetStore(store shareddata.SharedDataStore) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.store = store
+	if g.sharedDataManager != nil {
+		g.sharedDataManager.SetStore(store)
+	}
+}
+
+// Resume picks up the shared data kept in the store, once logged in.
+func (g *grapevine) Resume() ([]shareddata.SharedData, error) {
+	return g.sharedDataManager.Resume()
+}
+
 func (g *grapevine) Start(ip net.IP) (int, error) {
 	ctx := g.ctx.NewCtx("Start")
 	g.lock.Lock()
@@ -82,6 +130,12 @@ func (g *grapevine) Start(ip net.IP) (int, error) {
 	g.listener.SetClientCache(g.clientCache)
 
 	g.sharedDataManager = shareddata.NewSharedDataManager(ctx, g.listener, g.cb, g.clientCache)
+	if g.codec != nil {
+		g.sharedDataManager.SetCodec(g.codec)
+	}
+	if g.store != nil {
+		g.sharedDataManager.SetStore(g.store)
+	}
 	g.listener.SetSharedDataManager(g.sharedDataManager)
 
 	g.gossip = gossip.NewGossip(ctx, common.NewAddress(ip, port))
@@ -152,10 +206,44 @@ func (g *grapevine) Login(username string, password string, ip net.IP, port int)
 	}
 
 	g.accountId = common.NewAccountId(resp.GetUserId())
+	g.session = resp.GetSession()
 	g.listener.SetAccountId(g.accountId)
 	return g.accountId, nil
 }
 
+// ResolveContacts looks up where each account last logged in from, accounts
+// that never have, or that we may not invite, are left out.  We have to
+// Login first.
+func (g *grapevine) ResolveContacts(accountIds []common.AccountId) ([]common.Contact, error) {
+	log := g.ctx.NewCtx("ResolveContacts")
+	if g.session == "" {
+		return nil, ErrNotLoggedIn
+	}
+
+	log.Info().Msg("ResolveContacts: " + *authURL)
+	conn, err := grpc.Dial(*authURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := proto.NewContactServiceClient(conn)
+
+	resp, err := client.ResolveContacts(context.Background(), &proto.ResolveContactsRequest{
+		AccountIds: common.AccountIdsToStrings(accountIds),
+		Session:    g.session,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]common.Contact, 0, len(resp.GetContacts()))
+	for _, contact := range resp.GetContacts() {
+		contacts = append(contacts, common.NewContactFromPB(contact))
+	}
+	return contacts, nil
+}
+
 //------------------------------
 
 func (g *grapevine) Serve(s shareddata.SharedData) shareddata.SharedData {
@@ -163,9 +251,9 @@ func (g *grapevine) Serve(s shareddata.SharedData) shareddata.SharedData {
 	return g.sharedDataManager.Serve(s)
 }
 
-func (g *grapevine) JoinShare(s shareddata.SharedData) {
+func (g *grapevine) JoinShare(s shareddata.SharedData) (shareddata.SharedData, error) {
 	// Join a shared data
-	g.sharedDataManager.JoinShare(s)
+	return g.sharedDataManager.JoinShare(s)
 }
 
 func (g *grapevine) LeaveShare(s shareddata.SharedData) {
@@ -178,6 +266,55 @@ func (g *grapevine) Invite(s shareddata.SharedData, recipient common.Contact, as
 	return g.sharedDataManager.Invite(s, recipient, as)
 }
 
+// InviteAccount invites accountId to our shared data, wherever it last
+// logged in from.
+func (g *grapevine) InviteAccount(s shareddata.SharedData, accountId common.AccountId, as string) (bool, error) {
+	contacts, err := g.ResolveContacts([]common.AccountId{accountId})
+	if err != nil {
+		return false, err
+	}
+	if len(contacts) == 0 {
+		return false, fmt.Errorf("%w: %v", ErrUnknownAccount, accountId)
+	}
+	return g.sharedDataManager.Invite(s, contacts[0], as), nil
+}
+
+// SendInvite invites someone to our shared data without waiting for their
+// answer, which comes to the OnInviteAccepted or OnInviteDeclined callbacks.
+func (g *grapevine) SendInvite(s shareddata.SharedData, recipient common.Contact, as string, options shareddata.InviteOptions) (shareddata.Invitation, error) {
+	return g.sharedDataManager.SendInvite(s, recipient, as, options)
+}
+
+func (g *grapevine) RevokeInvite(id shareddata.InviteId) error {
+	return g.sharedDataManager.RevokeInvite(id)
+}
+
+func (g *grapevine) AcceptInvite(id shareddata.InviteId) (shareddata.SharedData, error) {
+	return g.sharedDataManager.AcceptInvite(id)
+}
+
+func (g *grapevine) DeclineInvite(id shareddata.InviteId, reason string) error {
+	return g.sharedDataManager.DeclineInvite(id, reason)
+}
+
+func (g *grapevine) IncomingInvites() []shareddata.Invitation {
+	return g.sharedDataManager.IncomingInvites()
+}
+
+func (g *grapevine) OutgoingInvites() []shareddata.Invitation {
+	return g.sharedDataManager.OutgoingInvites()
+}
+
+// CreateJoinToken makes a token, to pass on as a link, that lets whoever
+// has it join our shared data as the role as with JoinWithToken.
+func (g *grapevine) CreateJoinToken(s shareddata.SharedData, as string, expiry time.Duration) (string, error) {
+	return g.sharedDataManager.CreateJoinToken(s, as, expiry)
+}
+
+func (g *grapevine) JoinWithToken(token string) (shareddata.SharedData, error) {
+	return g.sharedDataManager.JoinWithToken(token)
+}
+
 // Initiating a search
 func (g *grapevine) Search(query string) shareddata.SearchId {
 	log := g.ctx.NewCtx("Search")
@@ -245,12 +245,34 @@ func (g *grapevineListener) Listen(ip net.IP) (int, error) {
 	mux.HandleFunc("/gossip", g.onGossip)
 	mux.HandleFunc("/searchresult", g.onSearchResult)
 	mux.HandleFunc("/shareddata/invite", g.onSharedData)
+	mux.HandleFunc("/shareddata/offer", g.onSharedData)
+	mux.HandleFunc("/shareddata/answer", g.onSharedData)
+	mux.HandleFunc("/shareddata/revoke", g.onSharedData)
+	mux.HandleFunc("/shareddata/redeem", g.onSharedData)
 	mux.HandleFunc("/shareddata/create", g.onSharedData)
+	mux.HandleFunc("/shareddata/createarray", g.onSharedData)
+	mux.HandleFunc("/shareddata/createmap", g.onSharedData)
 	mux.HandleFunc("/shareddata/changeowner", g.onSharedData)
 	mux.HandleFunc("/shareddata/set", g.onSharedData)
+	mux.HandleFunc("/shareddata/compareandset", g.onSharedData)
 	mux.HandleFunc("/shareddata/setmap", g.onSharedData)
 	mux.HandleFunc("/shareddata/append", g.onSharedData)
+	mux.HandleFunc("/shareddata/splice", g.onSharedData)
+	mux.HandleFunc("/shareddata/deletemapkey", g.onSharedData)
+	mux.HandleFunc("/shareddata/delete", g.onSharedData)
 	mux.HandleFunc("/shareddata/sendstate", g.onSharedData)
+	mux.HandleFunc("/shareddata/join", g.onSharedData)
+	mux.HandleFunc("/shareddata/getstate", g.onSharedData)
+	mux.HandleFunc("/shareddata/leave", g.onSharedData)
+	mux.HandleFunc("/shareddata/heartbeat", g.onSharedData)
+	mux.HandleFunc("/shareddata/host", g.onSharedData)
+	mux.HandleFunc("/shareddata/reassign", g.onSharedData)
+	mux.HandleFunc("/shareddata/requestownership", g.onSharedData)
+	mux.HandleFunc("/shareddata/setsuccessor", g.onSharedData)
+	mux.HandleFunc("/shareddata/definegroup", g.onSharedData)
+	mux.HandleFunc("/shareddata/setvisibility", g.onSharedData)
+	mux.HandleFunc("/shareddata/merge", g.onSharedData)
+	mux.HandleFunc("/shareddata/transaction", g.onSharedData)
 	// mux.HandleFunc("/data/invite", g.gossip)
 	// mux.HandleFunc("/data/change/owner", g.gossip)
 	// mux.HandleFunc("/data/change/data", g.gossip)
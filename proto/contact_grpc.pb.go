@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.23.3
+// source: proto/contact.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ContactService_ResolveContacts_FullMethodName = "/proto.ContactService/ResolveContacts"
+)
+
+// ContactServiceClient is the client API for ContactService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ContactServiceClient interface {
+	ResolveContacts(ctx context.Context, in *ResolveContactsRequest, opts ...grpc.CallOption) (*ResolveContactsResponse, error)
+}
+
+type contactServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewContactServiceClient(cc grpc.ClientConnInterface) ContactServiceClient {
+	return &contactServiceClient{cc}
+}
+
+func (c *contactServiceClient) ResolveContacts(ctx context.Context, in *ResolveContactsRequest, opts ...grpc.CallOption) (*ResolveContactsResponse, error) {
+	out := new(ResolveContactsResponse)
+	err := c.cc.Invoke(ctx, ContactService_ResolveContacts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ContactServiceServer is the server API for ContactService service.
+// All implementations must embed UnimplementedContactServiceServer
+// for forward compatibility
+type ContactServiceServer interface {
+	ResolveContacts(context.Context, *ResolveContactsRequest) (*ResolveContactsResponse, error)
+	mustEmbedUnimplementedContactServiceServer()
+}
+
+// UnimplementedContactServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedContactServiceServer struct {
+}
+
+func (UnimplementedContactServiceServer) ResolveContacts(context.Context, *ResolveContactsRequest) (*ResolveContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveContacts not implemented")
+}
+func (UnimplementedContactServiceServer) mustEmbedUnimplementedContactServiceServer() {}
+
+// UnsafeContactServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ContactServiceServer will
+// result in compilation errors.
+type UnsafeContactServiceServer interface {
+	mustEmbedUnimplementedContactServiceServer()
+}
+
+func RegisterContactServiceServer(s grpc.ServiceRegistrar, srv ContactServiceServer) {
+	s.RegisterService(&ContactService_ServiceDesc, srv)
+}
+
+func _ContactService_ResolveContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveContactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContactServiceServer).ResolveContacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContactService_ResolveContacts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContactServiceServer).ResolveContacts(ctx, req.(*ResolveContactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ContactService_ServiceDesc is the grpc.ServiceDesc for ContactService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ContactService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.ContactService",
+	HandlerType: (*ContactServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ResolveContacts",
+			Handler:    _ContactService_ResolveContacts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/contact.proto",
+}
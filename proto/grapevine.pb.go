@@ -686,14 +686,18 @@ func (*LeaveSharedDataResponse) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{13}
 }
 
+// SharedDataInvite names the schema of the share, if it has one, so a
+// client that doesn't know that version of it can refuse.
 type SharedDataInvite struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
-	Creator      *UserContact `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
-	As           string       `protobuf:"bytes,3,opt,name=as,proto3" json:"as,omitempty"`
+	SharedDataId  string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Creator       *UserContact `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
+	As            string       `protobuf:"bytes,3,opt,name=as,proto3" json:"as,omitempty"`
+	Schema        string       `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
+	SchemaVersion int32        `protobuf:"varint,5,opt,name=schemaVersion,proto3" json:"schemaVersion,omitempty"`
 }
 
 func (x *SharedDataInvite) Reset() {
@@ -749,6 +753,20 @@ func (x *SharedDataInvite) GetAs() string {
 	return ""
 }
 
+func (x *SharedDataInvite) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *SharedDataInvite) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
 type SharedDataInviteResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -796,21 +814,27 @@ func (x *SharedDataInviteResponse) GetAccepted() bool {
 	return false
 }
 
-type SharedDataCreate struct {
+// SharedDataOffer invites someone to a share without waiting for them to
+// answer, they reply with SharedDataAnswer whenever they decide.
+type SharedDataOffer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
-	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
-	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
-	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
-	Owner        string       `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
-	Visibility   string       `protobuf:"bytes,6,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	InviteId      string                 `protobuf:"bytes,1,opt,name=inviteId,proto3" json:"inviteId,omitempty"`
+	SharedDataId  string                 `protobuf:"bytes,2,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator    *UserContact           `protobuf:"bytes,3,opt,name=originator,proto3" json:"originator,omitempty"`
+	Creator       *UserContact           `protobuf:"bytes,4,opt,name=creator,proto3" json:"creator,omitempty"`
+	As            string                 `protobuf:"bytes,5,opt,name=as,proto3" json:"as,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Expires       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires,proto3" json:"expires,omitempty"`
+	Schema        string                 `protobuf:"bytes,9,opt,name=schema,proto3" json:"schema,omitempty"`
+	SchemaVersion int32                  `protobuf:"varint,10,opt,name=schemaVersion,proto3" json:"schemaVersion,omitempty"`
 }
 
-func (x *SharedDataCreate) Reset() {
-	*x = SharedDataCreate{}
+func (x *SharedDataOffer) Reset() {
+	*x = SharedDataOffer{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -818,13 +842,13 @@ func (x *SharedDataCreate) Reset() {
 	}
 }
 
-func (x *SharedDataCreate) String() string {
+func (x *SharedDataOffer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataCreate) ProtoMessage() {}
+func (*SharedDataOffer) ProtoMessage() {}
 
-func (x *SharedDataCreate) ProtoReflect() protoreflect.Message {
+func (x *SharedDataOffer) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -836,61 +860,89 @@ func (x *SharedDataCreate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataCreate.ProtoReflect.Descriptor instead.
-func (*SharedDataCreate) Descriptor() ([]byte, []int) {
+// Deprecated: Use SharedDataOffer.ProtoReflect.Descriptor instead.
+func (*SharedDataOffer) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *SharedDataCreate) GetSharedDataId() string {
+func (x *SharedDataOffer) GetInviteId() string {
+	if x != nil {
+		return x.InviteId
+	}
+	return ""
+}
+
+func (x *SharedDataOffer) GetSharedDataId() string {
 	if x != nil {
 		return x.SharedDataId
 	}
 	return ""
 }
 
-func (x *SharedDataCreate) GetOriginator() *UserContact {
+func (x *SharedDataOffer) GetOriginator() *UserContact {
 	if x != nil {
 		return x.Originator
 	}
 	return nil
 }
 
-func (x *SharedDataCreate) GetKey() string {
+func (x *SharedDataOffer) GetCreator() *UserContact {
 	if x != nil {
-		return x.Key
+		return x.Creator
+	}
+	return nil
+}
+
+func (x *SharedDataOffer) GetAs() string {
+	if x != nil {
+		return x.As
 	}
 	return ""
 }
 
-func (x *SharedDataCreate) GetValue() []byte {
+func (x *SharedDataOffer) GetMessage() string {
 	if x != nil {
-		return x.Value
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SharedDataOffer) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
 	}
 	return nil
 }
 
-func (x *SharedDataCreate) GetOwner() string {
+func (x *SharedDataOffer) GetExpires() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Owner
+		return x.Expires
 	}
-	return ""
+	return nil
 }
 
-func (x *SharedDataCreate) GetVisibility() string {
+func (x *SharedDataOffer) GetSchema() string {
 	if x != nil {
-		return x.Visibility
+		return x.Schema
 	}
 	return ""
 }
 
-type SharedDataCreateResponse struct {
+func (x *SharedDataOffer) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+type SharedDataOfferResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *SharedDataCreateResponse) Reset() {
-	*x = SharedDataCreateResponse{}
+func (x *SharedDataOfferResponse) Reset() {
+	*x = SharedDataOfferResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -898,13 +950,13 @@ func (x *SharedDataCreateResponse) Reset() {
 	}
 }
 
-func (x *SharedDataCreateResponse) String() string {
+func (x *SharedDataOfferResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataCreateResponse) ProtoMessage() {}
+func (*SharedDataOfferResponse) ProtoMessage() {}
 
-func (x *SharedDataCreateResponse) ProtoReflect() protoreflect.Message {
+func (x *SharedDataOfferResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -916,26 +968,26 @@ func (x *SharedDataCreateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataCreateResponse.ProtoReflect.Descriptor instead.
-func (*SharedDataCreateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SharedDataOfferResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataOfferResponse) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{17}
 }
 
-type SharedDataCreateArray struct {
+// SharedDataAnswer accepts or declines an offer, an accepted offer is
+// answered with the state of the share like a join.
+type SharedDataAnswer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
-	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
-	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
-	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
-	Owner        string       `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
-	Visibility   string       `protobuf:"bytes,6,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	InviteId   string       `protobuf:"bytes,1,opt,name=inviteId,proto3" json:"inviteId,omitempty"`
+	Originator *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Accepted   bool         `protobuf:"varint,3,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Reason     string       `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *SharedDataCreateArray) Reset() {
-	*x = SharedDataCreateArray{}
+func (x *SharedDataAnswer) Reset() {
+	*x = SharedDataAnswer{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -943,13 +995,13 @@ func (x *SharedDataCreateArray) Reset() {
 	}
 }
 
-func (x *SharedDataCreateArray) String() string {
+func (x *SharedDataAnswer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataCreateArray) ProtoMessage() {}
+func (*SharedDataAnswer) ProtoMessage() {}
 
-func (x *SharedDataCreateArray) ProtoReflect() protoreflect.Message {
+func (x *SharedDataAnswer) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -961,61 +1013,50 @@ func (x *SharedDataCreateArray) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataCreateArray.ProtoReflect.Descriptor instead.
-func (*SharedDataCreateArray) Descriptor() ([]byte, []int) {
+// Deprecated: Use SharedDataAnswer.ProtoReflect.Descriptor instead.
+func (*SharedDataAnswer) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *SharedDataCreateArray) GetSharedDataId() string {
+func (x *SharedDataAnswer) GetInviteId() string {
 	if x != nil {
-		return x.SharedDataId
+		return x.InviteId
 	}
 	return ""
 }
 
-func (x *SharedDataCreateArray) GetOriginator() *UserContact {
+func (x *SharedDataAnswer) GetOriginator() *UserContact {
 	if x != nil {
 		return x.Originator
 	}
 	return nil
 }
 
-func (x *SharedDataCreateArray) GetKey() string {
-	if x != nil {
-		return x.Key
-	}
-	return ""
-}
-
-func (x *SharedDataCreateArray) GetValue() []byte {
-	if x != nil {
-		return x.Value
-	}
-	return nil
-}
-
-func (x *SharedDataCreateArray) GetOwner() string {
+func (x *SharedDataAnswer) GetAccepted() bool {
 	if x != nil {
-		return x.Owner
+		return x.Accepted
 	}
-	return ""
+	return false
 }
 
-func (x *SharedDataCreateArray) GetVisibility() string {
+func (x *SharedDataAnswer) GetReason() string {
 	if x != nil {
-		return x.Visibility
+		return x.Reason
 	}
 	return ""
 }
 
-type SharedDataCreateArrayResponse struct {
+type SharedDataAnswerResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	State *SharedDataSendState `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Next  string               `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
 }
 
-func (x *SharedDataCreateArrayResponse) Reset() {
-	*x = SharedDataCreateArrayResponse{}
+func (x *SharedDataAnswerResponse) Reset() {
+	*x = SharedDataAnswerResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1023,13 +1064,13 @@ func (x *SharedDataCreateArrayResponse) Reset() {
 	}
 }
 
-func (x *SharedDataCreateArrayResponse) String() string {
+func (x *SharedDataAnswerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataCreateArrayResponse) ProtoMessage() {}
+func (*SharedDataAnswerResponse) ProtoMessage() {}
 
-func (x *SharedDataCreateArrayResponse) ProtoReflect() protoreflect.Message {
+func (x *SharedDataAnswerResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1041,26 +1082,36 @@ func (x *SharedDataCreateArrayResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataCreateArrayResponse.ProtoReflect.Descriptor instead.
-func (*SharedDataCreateArrayResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SharedDataAnswerResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataAnswerResponse) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{19}
 }
 
-type SharedDataCreateMap struct {
+func (x *SharedDataAnswerResponse) GetState() *SharedDataSendState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *SharedDataAnswerResponse) GetNext() string {
+	if x != nil {
+		return x.Next
+	}
+	return ""
+}
+
+type SharedDataRevoke struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
-	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
-	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
-	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
-	Owner        string       `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
-	Visibility   string       `protobuf:"bytes,6,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	InviteId   string       `protobuf:"bytes,1,opt,name=inviteId,proto3" json:"inviteId,omitempty"`
+	Originator *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
 }
 
-func (x *SharedDataCreateMap) Reset() {
-	*x = SharedDataCreateMap{}
+func (x *SharedDataRevoke) Reset() {
+	*x = SharedDataRevoke{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1068,13 +1119,13 @@ func (x *SharedDataCreateMap) Reset() {
 	}
 }
 
-func (x *SharedDataCreateMap) String() string {
+func (x *SharedDataRevoke) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataCreateMap) ProtoMessage() {}
+func (*SharedDataRevoke) ProtoMessage() {}
 
-func (x *SharedDataCreateMap) ProtoReflect() protoreflect.Message {
+func (x *SharedDataRevoke) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1086,61 +1137,33 @@ func (x *SharedDataCreateMap) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataCreateMap.ProtoReflect.Descriptor instead.
-func (*SharedDataCreateMap) Descriptor() ([]byte, []int) {
+// Deprecated: Use SharedDataRevoke.ProtoReflect.Descriptor instead.
+func (*SharedDataRevoke) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *SharedDataCreateMap) GetSharedDataId() string {
+func (x *SharedDataRevoke) GetInviteId() string {
 	if x != nil {
-		return x.SharedDataId
+		return x.InviteId
 	}
 	return ""
 }
 
-func (x *SharedDataCreateMap) GetOriginator() *UserContact {
+func (x *SharedDataRevoke) GetOriginator() *UserContact {
 	if x != nil {
 		return x.Originator
 	}
 	return nil
 }
 
-func (x *SharedDataCreateMap) GetKey() string {
-	if x != nil {
-		return x.Key
-	}
-	return ""
-}
-
-func (x *SharedDataCreateMap) GetValue() []byte {
-	if x != nil {
-		return x.Value
-	}
-	return nil
-}
-
-func (x *SharedDataCreateMap) GetOwner() string {
-	if x != nil {
-		return x.Owner
-	}
-	return ""
-}
-
-func (x *SharedDataCreateMap) GetVisibility() string {
-	if x != nil {
-		return x.Visibility
-	}
-	return ""
-}
-
-type SharedDataCreateMapResponse struct {
+type SharedDataRevokeResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *SharedDataCreateMapResponse) Reset() {
-	*x = SharedDataCreateMapResponse{}
+func (x *SharedDataRevokeResponse) Reset() {
+	*x = SharedDataRevokeResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1148,13 +1171,13 @@ func (x *SharedDataCreateMapResponse) Reset() {
 	}
 }
 
-func (x *SharedDataCreateMapResponse) String() string {
+func (x *SharedDataRevokeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataCreateMapResponse) ProtoMessage() {}
+func (*SharedDataRevokeResponse) ProtoMessage() {}
 
-func (x *SharedDataCreateMapResponse) ProtoReflect() protoreflect.Message {
+func (x *SharedDataRevokeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1166,24 +1189,26 @@ func (x *SharedDataCreateMapResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataCreateMapResponse.ProtoReflect.Descriptor instead.
-func (*SharedDataCreateMapResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SharedDataRevokeResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataRevokeResponse) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{21}
 }
 
-type SharedDataSet struct {
+// SharedDataJoinToken is what a join token says, the token is this signed by
+// the issuer, who admits anyone that presents it.
+type SharedDataJoinToken struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
-	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
-	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
-	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	SharedDataId string                 `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Issuer       *UserContact           `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	As           string                 `protobuf:"bytes,3,opt,name=as,proto3" json:"as,omitempty"`
+	Expires      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires,proto3" json:"expires,omitempty"`
 }
 
-func (x *SharedDataSet) Reset() {
-	*x = SharedDataSet{}
+func (x *SharedDataJoinToken) Reset() {
+	*x = SharedDataJoinToken{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1191,13 +1216,13 @@ func (x *SharedDataSet) Reset() {
 	}
 }
 
-func (x *SharedDataSet) String() string {
+func (x *SharedDataJoinToken) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataSet) ProtoMessage() {}
+func (*SharedDataJoinToken) ProtoMessage() {}
 
-func (x *SharedDataSet) ProtoReflect() protoreflect.Message {
+func (x *SharedDataJoinToken) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1209,47 +1234,50 @@ func (x *SharedDataSet) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataSet.ProtoReflect.Descriptor instead.
-func (*SharedDataSet) Descriptor() ([]byte, []int) {
+// Deprecated: Use SharedDataJoinToken.ProtoReflect.Descriptor instead.
+func (*SharedDataJoinToken) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *SharedDataSet) GetSharedDataId() string {
+func (x *SharedDataJoinToken) GetSharedDataId() string {
 	if x != nil {
 		return x.SharedDataId
 	}
 	return ""
 }
 
-func (x *SharedDataSet) GetOriginator() *UserContact {
+func (x *SharedDataJoinToken) GetIssuer() *UserContact {
 	if x != nil {
-		return x.Originator
+		return x.Issuer
 	}
 	return nil
 }
 
-func (x *SharedDataSet) GetKey() string {
+func (x *SharedDataJoinToken) GetAs() string {
 	if x != nil {
-		return x.Key
+		return x.As
 	}
 	return ""
 }
 
-func (x *SharedDataSet) GetValue() []byte {
+func (x *SharedDataJoinToken) GetExpires() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Value
+		return x.Expires
 	}
 	return nil
 }
 
-type SharedDataSetResponse struct {
+type SharedDataRedeem struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Token      string       `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Originator *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
 }
 
-func (x *SharedDataSetResponse) Reset() {
-	*x = SharedDataSetResponse{}
+func (x *SharedDataRedeem) Reset() {
+	*x = SharedDataRedeem{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1257,13 +1285,13 @@ func (x *SharedDataSetResponse) Reset() {
 	}
 }
 
-func (x *SharedDataSetResponse) String() string {
+func (x *SharedDataRedeem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataSetResponse) ProtoMessage() {}
+func (*SharedDataRedeem) ProtoMessage() {}
 
-func (x *SharedDataSetResponse) ProtoReflect() protoreflect.Message {
+func (x *SharedDataRedeem) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1275,25 +1303,36 @@ func (x *SharedDataSetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataSetResponse.ProtoReflect.Descriptor instead.
-func (*SharedDataSetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SharedDataRedeem.ProtoReflect.Descriptor instead.
+func (*SharedDataRedeem) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{23}
 }
 
-type SharedDataSetMap struct {
+func (x *SharedDataRedeem) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SharedDataRedeem) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+type SharedDataRedeemResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
-	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
-	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
-	MapKey       string       `protobuf:"bytes,4,opt,name=mapKey,proto3" json:"mapKey,omitempty"`
-	Value        []byte       `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	State *SharedDataSendState `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Next  string               `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
 }
 
-func (x *SharedDataSetMap) Reset() {
-	*x = SharedDataSetMap{}
+func (x *SharedDataRedeemResponse) Reset() {
+	*x = SharedDataRedeemResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1301,13 +1340,13 @@ func (x *SharedDataSetMap) Reset() {
 	}
 }
 
-func (x *SharedDataSetMap) String() string {
+func (x *SharedDataRedeemResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataSetMap) ProtoMessage() {}
+func (*SharedDataRedeemResponse) ProtoMessage() {}
 
-func (x *SharedDataSetMap) ProtoReflect() protoreflect.Message {
+func (x *SharedDataRedeemResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1319,54 +1358,42 @@ func (x *SharedDataSetMap) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataSetMap.ProtoReflect.Descriptor instead.
-func (*SharedDataSetMap) Descriptor() ([]byte, []int) {
+// Deprecated: Use SharedDataRedeemResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataRedeemResponse) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *SharedDataSetMap) GetSharedDataId() string {
-	if x != nil {
-		return x.SharedDataId
-	}
-	return ""
-}
-
-func (x *SharedDataSetMap) GetOriginator() *UserContact {
+func (x *SharedDataRedeemResponse) GetState() *SharedDataSendState {
 	if x != nil {
-		return x.Originator
+		return x.State
 	}
 	return nil
 }
 
-func (x *SharedDataSetMap) GetKey() string {
-	if x != nil {
-		return x.Key
-	}
-	return ""
-}
-
-func (x *SharedDataSetMap) GetMapKey() string {
+func (x *SharedDataRedeemResponse) GetNext() string {
 	if x != nil {
-		return x.MapKey
+		return x.Next
 	}
 	return ""
 }
 
-func (x *SharedDataSetMap) GetValue() []byte {
-	if x != nil {
-		return x.Value
-	}
-	return nil
-}
-
-type SharedDataSetMapResponse struct {
+type SharedDataCreate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Owner        string       `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	Visibility   string       `protobuf:"bytes,6,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Seq          uint64       `protobuf:"varint,7,opt,name=seq,proto3" json:"seq,omitempty"`
+	Codec        string       `protobuf:"bytes,8,opt,name=codec,proto3" json:"codec,omitempty"`
 }
 
-func (x *SharedDataSetMapResponse) Reset() {
-	*x = SharedDataSetMapResponse{}
+func (x *SharedDataCreate) Reset() {
+	*x = SharedDataCreate{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1374,13 +1401,13 @@ func (x *SharedDataSetMapResponse) Reset() {
 	}
 }
 
-func (x *SharedDataSetMapResponse) String() string {
+func (x *SharedDataCreate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataSetMapResponse) ProtoMessage() {}
+func (*SharedDataCreate) ProtoMessage() {}
 
-func (x *SharedDataSetMapResponse) ProtoReflect() protoreflect.Message {
+func (x *SharedDataCreate) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1392,105 +1419,90 @@ func (x *SharedDataSetMapResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataSetMapResponse.ProtoReflect.Descriptor instead.
-func (*SharedDataSetMapResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SharedDataCreate.ProtoReflect.Descriptor instead.
+func (*SharedDataCreate) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{25}
 }
 
-type SharedDataAppend struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
-	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
-	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
-	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
-}
-
-func (x *SharedDataAppend) Reset() {
-	*x = SharedDataAppend{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grapevine_proto_msgTypes[26]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *SharedDataCreate) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
 	}
+	return ""
 }
 
-func (x *SharedDataAppend) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SharedDataCreate) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
 }
 
-func (*SharedDataAppend) ProtoMessage() {}
-
-func (x *SharedDataAppend) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grapevine_proto_msgTypes[26]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *SharedDataCreate) GetKey() string {
+	if x != nil {
+		return x.Key
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use SharedDataAppend.ProtoReflect.Descriptor instead.
-func (*SharedDataAppend) Descriptor() ([]byte, []int) {
-	return file_proto_grapevine_proto_rawDescGZIP(), []int{26}
+func (x *SharedDataCreate) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
 }
 
-func (x *SharedDataAppend) GetSharedDataId() string {
+func (x *SharedDataCreate) GetOwner() string {
 	if x != nil {
-		return x.SharedDataId
+		return x.Owner
 	}
 	return ""
 }
 
-func (x *SharedDataAppend) GetOriginator() *UserContact {
+func (x *SharedDataCreate) GetVisibility() string {
 	if x != nil {
-		return x.Originator
+		return x.Visibility
 	}
-	return nil
+	return ""
 }
 
-func (x *SharedDataAppend) GetKey() string {
+func (x *SharedDataCreate) GetSeq() uint64 {
 	if x != nil {
-		return x.Key
+		return x.Seq
 	}
-	return ""
+	return 0
 }
 
-func (x *SharedDataAppend) GetValue() []byte {
+func (x *SharedDataCreate) GetCodec() string {
 	if x != nil {
-		return x.Value
+		return x.Codec
 	}
-	return nil
+	return ""
 }
 
-type SharedDataAppendResponse struct {
+type SharedDataCreateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *SharedDataAppendResponse) Reset() {
-	*x = SharedDataAppendResponse{}
+func (x *SharedDataCreateResponse) Reset() {
+	*x = SharedDataCreateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grapevine_proto_msgTypes[27]
+		mi := &file_proto_grapevine_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SharedDataAppendResponse) String() string {
+func (x *SharedDataCreateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataAppendResponse) ProtoMessage() {}
+func (*SharedDataCreateResponse) ProtoMessage() {}
 
-func (x *SharedDataAppendResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grapevine_proto_msgTypes[27]
+func (x *SharedDataCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1501,12 +1513,12 @@ func (x *SharedDataAppendResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataAppendResponse.ProtoReflect.Descriptor instead.
-func (*SharedDataAppendResponse) Descriptor() ([]byte, []int) {
-	return file_proto_grapevine_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use SharedDataCreateResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataCreateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{26}
 }
 
-type SharedDataChangeOwner struct {
+type SharedDataCreateArray struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -1514,26 +1526,30 @@ type SharedDataChangeOwner struct {
 	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
 	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
 	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
-	Owner        string       `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Owner        string       `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	Visibility   string       `protobuf:"bytes,6,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Seq          uint64       `protobuf:"varint,7,opt,name=seq,proto3" json:"seq,omitempty"`
+	Codec        string       `protobuf:"bytes,8,opt,name=codec,proto3" json:"codec,omitempty"`
 }
 
-func (x *SharedDataChangeOwner) Reset() {
-	*x = SharedDataChangeOwner{}
+func (x *SharedDataCreateArray) Reset() {
+	*x = SharedDataCreateArray{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grapevine_proto_msgTypes[28]
+		mi := &file_proto_grapevine_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SharedDataChangeOwner) String() string {
+func (x *SharedDataCreateArray) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataChangeOwner) ProtoMessage() {}
+func (*SharedDataCreateArray) ProtoMessage() {}
 
-func (x *SharedDataChangeOwner) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grapevine_proto_msgTypes[28]
+func (x *SharedDataCreateArray) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1544,62 +1560,90 @@ func (x *SharedDataChangeOwner) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataChangeOwner.ProtoReflect.Descriptor instead.
-func (*SharedDataChangeOwner) Descriptor() ([]byte, []int) {
-	return file_proto_grapevine_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use SharedDataCreateArray.ProtoReflect.Descriptor instead.
+func (*SharedDataCreateArray) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *SharedDataChangeOwner) GetSharedDataId() string {
+func (x *SharedDataCreateArray) GetSharedDataId() string {
 	if x != nil {
 		return x.SharedDataId
 	}
 	return ""
 }
 
-func (x *SharedDataChangeOwner) GetOriginator() *UserContact {
+func (x *SharedDataCreateArray) GetOriginator() *UserContact {
 	if x != nil {
 		return x.Originator
 	}
 	return nil
 }
 
-func (x *SharedDataChangeOwner) GetKey() string {
+func (x *SharedDataCreateArray) GetKey() string {
 	if x != nil {
 		return x.Key
 	}
 	return ""
 }
 
-func (x *SharedDataChangeOwner) GetOwner() string {
+func (x *SharedDataCreateArray) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SharedDataCreateArray) GetOwner() string {
 	if x != nil {
 		return x.Owner
 	}
 	return ""
 }
 
-type SharedDataChangeOwnerResponse struct {
+func (x *SharedDataCreateArray) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *SharedDataCreateArray) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SharedDataCreateArray) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+type SharedDataCreateArrayResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *SharedDataChangeOwnerResponse) Reset() {
-	*x = SharedDataChangeOwnerResponse{}
+func (x *SharedDataCreateArrayResponse) Reset() {
+	*x = SharedDataCreateArrayResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grapevine_proto_msgTypes[29]
+		mi := &file_proto_grapevine_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SharedDataChangeOwnerResponse) String() string {
+func (x *SharedDataCreateArrayResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataChangeOwnerResponse) ProtoMessage() {}
+func (*SharedDataCreateArrayResponse) ProtoMessage() {}
 
-func (x *SharedDataChangeOwnerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grapevine_proto_msgTypes[29]
+func (x *SharedDataCreateArrayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1610,38 +1654,43 @@ func (x *SharedDataChangeOwnerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataChangeOwnerResponse.ProtoReflect.Descriptor instead.
-func (*SharedDataChangeOwnerResponse) Descriptor() ([]byte, []int) {
-	return file_proto_grapevine_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use SharedDataCreateArrayResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataCreateArrayResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{28}
 }
 
-type SharedDataData struct {
+type SharedDataCreateMap struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Value     []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	Owner     string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
-	Visbility string `protobuf:"bytes,3,opt,name=visbility,proto3" json:"visbility,omitempty"`
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Owner        string       `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	Visibility   string       `protobuf:"bytes,6,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Seq          uint64       `protobuf:"varint,7,opt,name=seq,proto3" json:"seq,omitempty"`
+	Codec        string       `protobuf:"bytes,8,opt,name=codec,proto3" json:"codec,omitempty"`
 }
 
-func (x *SharedDataData) Reset() {
-	*x = SharedDataData{}
+func (x *SharedDataCreateMap) Reset() {
+	*x = SharedDataCreateMap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_grapevine_proto_msgTypes[30]
+		mi := &file_proto_grapevine_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SharedDataData) String() string {
+func (x *SharedDataCreateMap) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataData) ProtoMessage() {}
+func (*SharedDataCreateMap) ProtoMessage() {}
 
-func (x *SharedDataData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_grapevine_proto_msgTypes[30]
+func (x *SharedDataCreateMap) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1652,45 +1701,121 @@ func (x *SharedDataData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataData.ProtoReflect.Descriptor instead.
-func (*SharedDataData) Descriptor() ([]byte, []int) {
-	return file_proto_grapevine_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use SharedDataCreateMap.ProtoReflect.Descriptor instead.
+func (*SharedDataCreateMap) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *SharedDataData) GetValue() []byte {
+func (x *SharedDataCreateMap) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataCreateMap) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataCreateMap) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataCreateMap) GetValue() []byte {
 	if x != nil {
 		return x.Value
 	}
 	return nil
 }
 
-func (x *SharedDataData) GetOwner() string {
+func (x *SharedDataCreateMap) GetOwner() string {
 	if x != nil {
 		return x.Owner
 	}
 	return ""
 }
 
-func (x *SharedDataData) GetVisbility() string {
+func (x *SharedDataCreateMap) GetVisibility() string {
 	if x != nil {
-		return x.Visbility
+		return x.Visibility
 	}
 	return ""
 }
 
-type SharedDataSendState struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	SharedDataId string                     `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
-	Originator   *UserContact               `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
-	Data         map[string]*SharedDataData `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Listeners    map[string]*UserContact    `protobuf:"bytes,4,rep,name=listeners,proto3" json:"listeners,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+func (x *SharedDataCreateMap) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
 }
 
-func (x *SharedDataSendState) Reset() {
-	*x = SharedDataSendState{}
+func (x *SharedDataCreateMap) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+type SharedDataCreateMapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataCreateMapResponse) Reset() {
+	*x = SharedDataCreateMapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataCreateMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataCreateMapResponse) ProtoMessage() {}
+
+func (x *SharedDataCreateMapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataCreateMapResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataCreateMapResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{30}
+}
+
+type SharedDataSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Seq          uint64       `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+	Codec        string       `protobuf:"bytes,6,opt,name=codec,proto3" json:"codec,omitempty"`
+	Path         string       `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *SharedDataSet) Reset() {
+	*x = SharedDataSet{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1698,13 +1823,13 @@ func (x *SharedDataSendState) Reset() {
 	}
 }
 
-func (x *SharedDataSendState) String() string {
+func (x *SharedDataSet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataSendState) ProtoMessage() {}
+func (*SharedDataSet) ProtoMessage() {}
 
-func (x *SharedDataSendState) ProtoReflect() protoreflect.Message {
+func (x *SharedDataSet) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1716,47 +1841,68 @@ func (x *SharedDataSendState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SharedDataSendState.ProtoReflect.Descriptor instead.
-func (*SharedDataSendState) Descriptor() ([]byte, []int) {
+// Deprecated: Use SharedDataSet.ProtoReflect.Descriptor instead.
+func (*SharedDataSet) Descriptor() ([]byte, []int) {
 	return file_proto_grapevine_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *SharedDataSendState) GetSharedDataId() string {
+func (x *SharedDataSet) GetSharedDataId() string {
 	if x != nil {
 		return x.SharedDataId
 	}
 	return ""
 }
 
-func (x *SharedDataSendState) GetOriginator() *UserContact {
+func (x *SharedDataSet) GetOriginator() *UserContact {
 	if x != nil {
 		return x.Originator
 	}
 	return nil
 }
 
-func (x *SharedDataSendState) GetData() map[string]*SharedDataData {
+func (x *SharedDataSet) GetKey() string {
 	if x != nil {
-		return x.Data
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-func (x *SharedDataSendState) GetListeners() map[string]*UserContact {
+func (x *SharedDataSet) GetValue() []byte {
 	if x != nil {
-		return x.Listeners
+		return x.Value
 	}
 	return nil
 }
 
-type SharedDataSendStateResponse struct {
+func (x *SharedDataSet) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SharedDataSet) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *SharedDataSet) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type SharedDataSetResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *SharedDataSendStateResponse) Reset() {
-	*x = SharedDataSendStateResponse{}
+func (x *SharedDataSetResponse) Reset() {
+	*x = SharedDataSetResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_grapevine_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1764,358 +1910,4465 @@ func (x *SharedDataSendStateResponse) Reset() {
 	}
 }
 
-func (x *SharedDataSendStateResponse) String() string {
+func (x *SharedDataSetResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SharedDataSendStateResponse) ProtoMessage() {}
+func (*SharedDataSetResponse) ProtoMessage() {}
 
-func (x *SharedDataSendStateResponse) ProtoReflect() protoreflect.Message {
+func (x *SharedDataSetResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_grapevine_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use SharedDataSendStateResponse.ProtoReflect.Descriptor instead.
-func (*SharedDataSendStateResponse) Descriptor() ([]byte, []int) {
-	return file_proto_grapevine_proto_rawDescGZIP(), []int{32}
-}
-
-var File_proto_grapevine_proto protoreflect.FileDescriptor
-
-var file_proto_grapevine_proto_rawDesc = []byte{
-	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x65, 0x76, 0x69, 0x6e,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
-	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
-	0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x6d, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1b, 0x0a,
-	0x09, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x09, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
-	0x74, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05,
-	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65,
-	0x72, 0x79, 0x22, 0x80, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x09,
-	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b,
-	0x0a, 0x09, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x09, 0x72,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61,
-	0x63, 0x74, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a,
-	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x7a, 0x0a, 0x06, 0x47, 0x6f, 0x73,
-	0x73, 0x69, 0x70, 0x12, 0x38, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x4f, 0x66, 0x4c, 0x69, 0x66, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x4f, 0x66, 0x4c, 0x69, 0x66, 0x65, 0x12, 0x27, 0x0a,
-	0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x00, 0x52, 0x06,
-	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x42, 0x0d, 0x0a, 0x0b, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70,
-	0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0x36, 0x0a, 0x0d, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x06, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47,
-	0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x06, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x22, 0x37, 0x0a,
-	0x0e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x25, 0x0a, 0x06, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x06,
-	0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x22, 0x19, 0x0a, 0x17, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x1a, 0x0a, 0x18, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x49, 0x6e, 0x76, 0x69, 0x74,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x0a,
-	0x16, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x4f, 0x77, 0x6e, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x19, 0x0a, 0x17, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x44, 0x61, 0x74, 0x61, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x0a,
-	0x16, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x19, 0x0a, 0x17, 0x4c, 0x65, 0x61, 0x76, 0x65,
-	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x74, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
-	0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68,
-	0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x07, 0x63, 0x72,
-	0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52,
-	0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x61, 0x73, 0x22, 0x36, 0x0a, 0x18, 0x53, 0x68, 0x61, 0x72,
-	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64,
-	0x22, 0xc8, 0x01, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
-	0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61,
-	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69,
-	0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
-	0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x76,
-	0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x1a, 0x0a, 0x18, 0x53,
-	0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xcd, 0x01, 0x0a, 0x15, 0x53, 0x68, 0x61, 0x72,
-	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x72, 0x72, 0x61,
-	0x79, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
-	0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61,
-	0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f,
-	0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62,
-	0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x69, 0x73,
-	0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x1f, 0x0a, 0x1d, 0x53, 0x68, 0x61, 0x72, 0x65,
-	0x64, 0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xcb, 0x01, 0x0a, 0x13, 0x53, 0x68, 0x61,
-	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x70,
-	0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
-	0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74,
-	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72,
-	0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69,
-	0x6c, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69,
-	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x1d, 0x0a, 0x1b, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x8f, 0x01, 0x0a, 0x0d, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65,
-	0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73,
-	0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f,
-	0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74,
-	0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x53, 0x68, 0x61, 0x72, 0x65,
-	0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0xaa, 0x01, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53,
-	0x65, 0x74, 0x4d, 0x61, 0x70, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
-	0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61,
-	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69,
-	0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
-	0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x16, 0x0a, 0x06, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1a, 0x0a,
-	0x18, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x4d, 0x61,
-	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x92, 0x01, 0x0a, 0x10, 0x53, 0x68,
-	0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x12, 0x22,
-	0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
-	0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55,
-	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67,
-	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1a,
-	0x0a, 0x18, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x41, 0x70, 0x70, 0x65,
-	0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x97, 0x01, 0x0a, 0x15, 0x53,
-	0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4f,
-	0x77, 0x6e, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
-	0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72,
-	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67,
-	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74,
-	0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f,
-	0x77, 0x6e, 0x65, 0x72, 0x22, 0x1f, 0x0a, 0x1d, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
-	0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5a, 0x0a, 0x0e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
-	0x61, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77,
-	0x6e, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x69, 0x73, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x69, 0x73, 0x62, 0x69, 0x6c, 0x69, 0x74,
-	0x79, 0x22, 0x92, 0x03, 0x0a, 0x13, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
-	0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61,
-	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a,
-	0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f,
-	0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
-	0x72, 0x12, 0x38, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
-	0x74, 0x61, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x09, 0x6c,
-	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
-	0x61, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65,
-	0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65,
-	0x6e, 0x65, 0x72, 0x73, 0x1a, 0x4e, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65,
-	0x64, 0x44, 0x61, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x50, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1d, 0x0a, 0x1b, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xce, 0x03, 0x0a, 0x10, 0x47, 0x72, 0x61, 0x70, 0x65, 0x76,
-	0x69, 0x6e, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x47, 0x6f,
-	0x73, 0x73, 0x69, 0x70, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x73,
-	0x73, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x47, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x53, 0x68,
-	0x61, 0x72, 0x65, 0x64, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x49, 0x6e, 0x76,
-	0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x49, 0x6e, 0x76,
-	0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x50, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x4f, 0x77, 0x6e,
-	0x65, 0x72, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x44, 0x61, 0x74, 0x61, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x44, 0x61, 0x74, 0x61, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x41, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12,
-	0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61,
-	0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0f, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x53, 0x68, 0x61,
-	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x4c, 0x65, 0x61, 0x76, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c,
-	0x65, 0x61, 0x76, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x6f, 0x79, 0x6c, 0x65, 0x31, 0x39, 0x37, 0x34, 0x2f, 0x67,
-	0x72, 0x61, 0x70, 0x65, 0x76, 0x69, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_proto_grapevine_proto_rawDescOnce sync.Once
-	file_proto_grapevine_proto_rawDescData = file_proto_grapevine_proto_rawDesc
-)
-
-func file_proto_grapevine_proto_rawDescGZIP() []byte {
-	file_proto_grapevine_proto_rawDescOnce.Do(func() {
-		file_proto_grapevine_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_grapevine_proto_rawDescData)
-	})
-	return file_proto_grapevine_proto_rawDescData
-}
-
-var file_proto_grapevine_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
-var file_proto_grapevine_proto_goTypes = []interface{}{
-	(*Search)(nil),                        // 0: proto.Search
-	(*SearchResultRequest)(nil),           // 1: proto.SearchResultRequest
-	(*SearchResultResponse)(nil),          // 2: proto.SearchResultResponse
-	(*Gossip)(nil),                        // 3: proto.Gossip
-	(*GossipRequest)(nil),                 // 4: proto.GossipRequest
-	(*GossipResponse)(nil),                // 5: proto.GossipResponse
-	(*SharedInvitationRequest)(nil),       // 6: proto.SharedInvitationRequest
-	(*SharedInvitationResponse)(nil),      // 7: proto.SharedInvitationResponse
-	(*ChangeDataOwnerRequest)(nil),        // 8: proto.ChangeDataOwnerRequest
-	(*ChangeDataOwnerResponse)(nil),       // 9: proto.ChangeDataOwnerResponse
-	(*ChangeDataRequest)(nil),             // 10: proto.ChangeDataRequest
-	(*ChangeDataResponse)(nil),            // 11: proto.ChangeDataResponse
-	(*LeaveSharedDataRequest)(nil),        // 12: proto.LeaveSharedDataRequest
-	(*LeaveSharedDataResponse)(nil),       // 13: proto.LeaveSharedDataResponse
-	(*SharedDataInvite)(nil),              // 14: proto.SharedDataInvite
-	(*SharedDataInviteResponse)(nil),      // 15: proto.SharedDataInviteResponse
-	(*SharedDataCreate)(nil),              // 16: proto.SharedDataCreate
-	(*SharedDataCreateResponse)(nil),      // 17: proto.SharedDataCreateResponse
-	(*SharedDataCreateArray)(nil),         // 18: proto.SharedDataCreateArray
-	(*SharedDataCreateArrayResponse)(nil), // 19: proto.SharedDataCreateArrayResponse
-	(*SharedDataCreateMap)(nil),           // 20: proto.SharedDataCreateMap
-	(*SharedDataCreateMapResponse)(nil),   // 21: proto.SharedDataCreateMapResponse
-	(*SharedDataSet)(nil),                 // 22: proto.SharedDataSet
-	(*SharedDataSetResponse)(nil),         // 23: proto.SharedDataSetResponse
-	(*SharedDataSetMap)(nil),              // 24: proto.SharedDataSetMap
-	(*SharedDataSetMapResponse)(nil),      // 25: proto.SharedDataSetMapResponse
-	(*SharedDataAppend)(nil),              // 26: proto.SharedDataAppend
-	(*SharedDataAppendResponse)(nil),      // 27: proto.SharedDataAppendResponse
-	(*SharedDataChangeOwner)(nil),         // 28: proto.SharedDataChangeOwner
-	(*SharedDataChangeOwnerResponse)(nil), // 29: proto.SharedDataChangeOwnerResponse
-	(*SharedDataData)(nil),                // 30: proto.SharedDataData
-	(*SharedDataSendState)(nil),           // 31: proto.SharedDataSendState
-	(*SharedDataSendStateResponse)(nil),   // 32: proto.SharedDataSendStateResponse
-	nil,                                   // 33: proto.SharedDataSendState.DataEntry
-	nil,                                   // 34: proto.SharedDataSendState.ListenersEntry
-	(*UserContact)(nil),                   // 35: proto.UserContact
-	(*timestamppb.Timestamp)(nil),         // 36: google.protobuf.Timestamp
-}
-var file_proto_grapevine_proto_depIdxs = []int32{
-	35, // 0: proto.Search.requestor:type_name -> proto.UserContact
-	35, // 1: proto.SearchResultRequest.responder:type_name -> proto.UserContact
-	35, // 2: proto.SearchResultResponse.responder:type_name -> proto.UserContact
-	36, // 3: proto.Gossip.endOfLife:type_name -> google.protobuf.Timestamp
-	0,  // 4: proto.Gossip.search:type_name -> proto.Search
-	3,  // 5: proto.GossipRequest.gossip:type_name -> proto.Gossip
-	3,  // 6: proto.GossipResponse.gossip:type_name -> proto.Gossip
-	35, // 7: proto.SharedDataInvite.creator:type_name -> proto.UserContact
-	35, // 8: proto.SharedDataCreate.originator:type_name -> proto.UserContact
-	35, // 9: proto.SharedDataCreateArray.originator:type_name -> proto.UserContact
-	35, // 10: proto.SharedDataCreateMap.originator:type_name -> proto.UserContact
-	35, // 11: proto.SharedDataSet.originator:type_name -> proto.UserContact
-	35, // 12: proto.SharedDataSetMap.originator:type_name -> proto.UserContact
-	35, // 13: proto.SharedDataAppend.originator:type_name -> proto.UserContact
-	35, // 14: proto.SharedDataChangeOwner.originator:type_name -> proto.UserContact
-	35, // 15: proto.SharedDataSendState.originator:type_name -> proto.UserContact
-	33, // 16: proto.SharedDataSendState.data:type_name -> proto.SharedDataSendState.DataEntry
-	34, // 17: proto.SharedDataSendState.listeners:type_name -> proto.SharedDataSendState.ListenersEntry
-	30, // 18: proto.SharedDataSendState.DataEntry.value:type_name -> proto.SharedDataData
-	35, // 19: proto.SharedDataSendState.ListenersEntry.value:type_name -> proto.UserContact
-	4,  // 20: proto.GrapevineService.Gossip:input_type -> proto.GossipRequest
-	1,  // 21: proto.GrapevineService.SearchResult:input_type -> proto.SearchResultRequest
-	6,  // 22: proto.GrapevineService.SharedInvitation:input_type -> proto.SharedInvitationRequest
-	8,  // 23: proto.GrapevineService.ChangeDataOwner:input_type -> proto.ChangeDataOwnerRequest
-	10, // 24: proto.GrapevineService.ChangeData:input_type -> proto.ChangeDataRequest
-	12, // 25: proto.GrapevineService.LeaveSharedData:input_type -> proto.LeaveSharedDataRequest
-	5,  // 26: proto.GrapevineService.Gossip:output_type -> proto.GossipResponse
-	2,  // 27: proto.GrapevineService.SearchResult:output_type -> proto.SearchResultResponse
-	7,  // 28: proto.GrapevineService.SharedInvitation:output_type -> proto.SharedInvitationResponse
-	9,  // 29: proto.GrapevineService.ChangeDataOwner:output_type -> proto.ChangeDataOwnerResponse
-	11, // 30: proto.GrapevineService.ChangeData:output_type -> proto.ChangeDataResponse
-	13, // 31: proto.GrapevineService.LeaveSharedData:output_type -> proto.LeaveSharedDataResponse
-	26, // [26:32] is the sub-list for method output_type
-	20, // [20:26] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
-}
-
-func init() { file_proto_grapevine_proto_init() }
-func file_proto_grapevine_proto_init() {
-	if File_proto_grapevine_proto != nil {
-		return
-	}
-	file_proto_common_proto_init()
-	if !protoimpl.UnsafeEnabled {
-		file_proto_grapevine_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Search); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataSetResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataSetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{32}
+}
+
+// SharedDataCompareAndSet asks the host to decide a compare and set, which
+// it sends on to every member with author, the member that asked, set.
+type SharedDataCompareAndSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Codec        string       `protobuf:"bytes,5,opt,name=codec,proto3" json:"codec,omitempty"`
+	Version      uint64       `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	Seq          uint64       `protobuf:"varint,7,opt,name=seq,proto3" json:"seq,omitempty"`
+	Author       *UserContact `protobuf:"bytes,8,opt,name=author,proto3" json:"author,omitempty"`
+}
+
+func (x *SharedDataCompareAndSet) Reset() {
+	*x = SharedDataCompareAndSet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataCompareAndSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataCompareAndSet) ProtoMessage() {}
+
+func (x *SharedDataCompareAndSet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataCompareAndSet.ProtoReflect.Descriptor instead.
+func (*SharedDataCompareAndSet) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SharedDataCompareAndSet) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataCompareAndSet) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataCompareAndSet) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataCompareAndSet) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SharedDataCompareAndSet) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *SharedDataCompareAndSet) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SharedDataCompareAndSet) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SharedDataCompareAndSet) GetAuthor() *UserContact {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+type SharedDataCompareAndSetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataCompareAndSetResponse) Reset() {
+	*x = SharedDataCompareAndSetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataCompareAndSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataCompareAndSetResponse) ProtoMessage() {}
+
+func (x *SharedDataCompareAndSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataCompareAndSetResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataCompareAndSetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{34}
+}
+
+type SharedDataSetMap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	MapKey       string       `protobuf:"bytes,4,opt,name=mapKey,proto3" json:"mapKey,omitempty"`
+	Value        []byte       `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Seq          uint64       `protobuf:"varint,6,opt,name=seq,proto3" json:"seq,omitempty"`
+	Codec        string       `protobuf:"bytes,7,opt,name=codec,proto3" json:"codec,omitempty"`
+}
+
+func (x *SharedDataSetMap) Reset() {
+	*x = SharedDataSetMap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataSetMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataSetMap) ProtoMessage() {}
+
+func (x *SharedDataSetMap) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataSetMap.ProtoReflect.Descriptor instead.
+func (*SharedDataSetMap) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SharedDataSetMap) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataSetMap) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataSetMap) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataSetMap) GetMapKey() string {
+	if x != nil {
+		return x.MapKey
+	}
+	return ""
+}
+
+func (x *SharedDataSetMap) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SharedDataSetMap) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SharedDataSetMap) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+type SharedDataSetMapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataSetMapResponse) Reset() {
+	*x = SharedDataSetMapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataSetMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataSetMapResponse) ProtoMessage() {}
+
+func (x *SharedDataSetMapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataSetMapResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataSetMapResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{36}
+}
+
+type SharedDataAppend struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Seq          uint64       `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+	Codec        string       `protobuf:"bytes,6,opt,name=codec,proto3" json:"codec,omitempty"`
+	Path         string       `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *SharedDataAppend) Reset() {
+	*x = SharedDataAppend{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataAppend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataAppend) ProtoMessage() {}
+
+func (x *SharedDataAppend) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataAppend.ProtoReflect.Descriptor instead.
+func (*SharedDataAppend) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SharedDataAppend) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataAppend) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataAppend) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataAppend) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SharedDataAppend) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SharedDataAppend) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *SharedDataAppend) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type SharedDataAppendResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataAppendResponse) Reset() {
+	*x = SharedDataAppendResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataAppendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataAppendResponse) ProtoMessage() {}
+
+func (x *SharedDataAppendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataAppendResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataAppendResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{38}
+}
+
+type SharedDataChangeOwner struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Owner        string       `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	Seq          uint64       `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+	Path         string       `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *SharedDataChangeOwner) Reset() {
+	*x = SharedDataChangeOwner{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataChangeOwner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataChangeOwner) ProtoMessage() {}
+
+func (x *SharedDataChangeOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataChangeOwner.ProtoReflect.Descriptor instead.
+func (*SharedDataChangeOwner) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SharedDataChangeOwner) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataChangeOwner) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataChangeOwner) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataChangeOwner) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SharedDataChangeOwner) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SharedDataChangeOwner) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type SharedDataChangeOwnerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataChangeOwnerResponse) Reset() {
+	*x = SharedDataChangeOwnerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataChangeOwnerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataChangeOwnerResponse) ProtoMessage() {}
+
+func (x *SharedDataChangeOwnerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataChangeOwnerResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataChangeOwnerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{40}
+}
+
+type SharedDataData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value        []byte            `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Owner        string            `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Visbility    string            `protobuf:"bytes,3,opt,name=visbility,proto3" json:"visbility,omitempty"`
+	Codec        string            `protobuf:"bytes,4,opt,name=codec,proto3" json:"codec,omitempty"`
+	Version      uint64            `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	Successor    string            `protobuf:"bytes,6,opt,name=successor,proto3" json:"successor,omitempty"`
+	Owners       map[string]string `protobuf:"bytes,7,rep,name=owners,proto3" json:"owners,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Visibilities map[string]string `protobuf:"bytes,8,rep,name=visibilities,proto3" json:"visibilities,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *SharedDataData) Reset() {
+	*x = SharedDataData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataData) ProtoMessage() {}
+
+func (x *SharedDataData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataData.ProtoReflect.Descriptor instead.
+func (*SharedDataData) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SharedDataData) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SharedDataData) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SharedDataData) GetVisbility() string {
+	if x != nil {
+		return x.Visbility
+	}
+	return ""
+}
+
+func (x *SharedDataData) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *SharedDataData) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SharedDataData) GetSuccessor() string {
+	if x != nil {
+		return x.Successor
+	}
+	return ""
+}
+
+func (x *SharedDataData) GetOwners() map[string]string {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *SharedDataData) GetVisibilities() map[string]string {
+	if x != nil {
+		return x.Visibilities
+	}
+	return nil
+}
+
+type SharedDataGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Roles []string `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+}
+
+func (x *SharedDataGroup) Reset() {
+	*x = SharedDataGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataGroup) ProtoMessage() {}
+
+func (x *SharedDataGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataGroup.ProtoReflect.Descriptor instead.
+func (*SharedDataGroup) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SharedDataGroup) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type SharedDataSendState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId  string                      `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator    *UserContact                `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Data          map[string]*SharedDataData  `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Listeners     map[string]*UserContact     `protobuf:"bytes,4,rep,name=listeners,proto3" json:"listeners,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Groups        map[string]*SharedDataGroup `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	History       []*SharedDataHistoryEntry   `protobuf:"bytes,6,rep,name=history,proto3" json:"history,omitempty"`
+	Deleted       []string                    `protobuf:"bytes,7,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	Partial       bool                        `protobuf:"varint,8,opt,name=partial,proto3" json:"partial,omitempty"`
+	Host          string                      `protobuf:"bytes,9,opt,name=host,proto3" json:"host,omitempty"`
+	Schema        string                      `protobuf:"bytes,10,opt,name=schema,proto3" json:"schema,omitempty"`
+	SchemaVersion int32                       `protobuf:"varint,11,opt,name=schemaVersion,proto3" json:"schemaVersion,omitempty"`
+	Creator       *UserContact                `protobuf:"bytes,12,opt,name=creator,proto3" json:"creator,omitempty"`
+}
+
+func (x *SharedDataSendState) Reset() {
+	*x = SharedDataSendState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataSendState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataSendState) ProtoMessage() {}
+
+func (x *SharedDataSendState) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataSendState.ProtoReflect.Descriptor instead.
+func (*SharedDataSendState) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SharedDataSendState) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataSendState) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataSendState) GetData() map[string]*SharedDataData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SharedDataSendState) GetListeners() map[string]*UserContact {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+func (x *SharedDataSendState) GetGroups() map[string]*SharedDataGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *SharedDataSendState) GetHistory() []*SharedDataHistoryEntry {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *SharedDataSendState) GetDeleted() []string {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+func (x *SharedDataSendState) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *SharedDataSendState) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *SharedDataSendState) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *SharedDataSendState) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *SharedDataSendState) GetCreator() *UserContact {
+	if x != nil {
+		return x.Creator
+	}
+	return nil
+}
+
+// SharedDataHistoryEntry is a change kept in the history of a share, before
+// and after are only sent to members that can see the key.
+type SharedDataHistoryEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key        string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	MapKey     string                 `protobuf:"bytes,2,opt,name=mapKey,proto3" json:"mapKey,omitempty"`
+	Index      int64                  `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Kind       int32                  `protobuf:"varint,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Before     []byte                 `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`
+	After      []byte                 `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`
+	Codec      string                 `protobuf:"bytes,7,opt,name=codec,proto3" json:"codec,omitempty"`
+	Originator *UserContact           `protobuf:"bytes,8,opt,name=originator,proto3" json:"originator,omitempty"`
+	Time       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=time,proto3" json:"time,omitempty"`
+	Version    uint64                 `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	Visibility string                 `protobuf:"bytes,11,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Path       string                 `protobuf:"bytes,12,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *SharedDataHistoryEntry) Reset() {
+	*x = SharedDataHistoryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataHistoryEntry) ProtoMessage() {}
+
+func (x *SharedDataHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataHistoryEntry.ProtoReflect.Descriptor instead.
+func (*SharedDataHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SharedDataHistoryEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataHistoryEntry) GetMapKey() string {
+	if x != nil {
+		return x.MapKey
+	}
+	return ""
+}
+
+func (x *SharedDataHistoryEntry) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SharedDataHistoryEntry) GetKind() int32 {
+	if x != nil {
+		return x.Kind
+	}
+	return 0
+}
+
+func (x *SharedDataHistoryEntry) GetBefore() []byte {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *SharedDataHistoryEntry) GetAfter() []byte {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *SharedDataHistoryEntry) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *SharedDataHistoryEntry) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataHistoryEntry) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *SharedDataHistoryEntry) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SharedDataHistoryEntry) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *SharedDataHistoryEntry) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type SharedDataSendStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataSendStateResponse) Reset() {
+	*x = SharedDataSendStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataSendStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataSendStateResponse) ProtoMessage() {}
+
+func (x *SharedDataSendStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataSendStateResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataSendStateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{45}
+}
+
+// SharedDataJoin is sent by a member rejoining with the versions of the
+// keys it still has, it is only sent the keys that are newer.  With
+// announce it is sent by announcer, the member that admitted originator,
+// to the others.
+type SharedDataJoin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string            `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact      `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	As           string            `protobuf:"bytes,3,opt,name=as,proto3" json:"as,omitempty"`
+	Announce     bool              `protobuf:"varint,4,opt,name=announce,proto3" json:"announce,omitempty"`
+	Versions     map[string]uint64 `protobuf:"bytes,5,rep,name=versions,proto3" json:"versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Announcer    *UserContact      `protobuf:"bytes,6,opt,name=announcer,proto3" json:"announcer,omitempty"`
+}
+
+func (x *SharedDataJoin) Reset() {
+	*x = SharedDataJoin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataJoin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataJoin) ProtoMessage() {}
+
+func (x *SharedDataJoin) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataJoin.ProtoReflect.Descriptor instead.
+func (*SharedDataJoin) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SharedDataJoin) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataJoin) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataJoin) GetAs() string {
+	if x != nil {
+		return x.As
+	}
+	return ""
+}
+
+func (x *SharedDataJoin) GetAnnounce() bool {
+	if x != nil {
+		return x.Announce
+	}
+	return false
+}
+
+func (x *SharedDataJoin) GetVersions() map[string]uint64 {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *SharedDataJoin) GetAnnouncer() *UserContact {
+	if x != nil {
+		return x.Announcer
+	}
+	return nil
+}
+
+// SharedDataJoinResponse holds the first part of the state, if next is set
+// the rest is fetched with SharedDataGetState.
+type SharedDataJoinResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accepted bool                 `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	State    *SharedDataSendState `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Versions map[string]uint64    `protobuf:"bytes,3,rep,name=versions,proto3" json:"versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Next     string               `protobuf:"bytes,4,opt,name=next,proto3" json:"next,omitempty"`
+}
+
+func (x *SharedDataJoinResponse) Reset() {
+	*x = SharedDataJoinResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataJoinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataJoinResponse) ProtoMessage() {}
+
+func (x *SharedDataJoinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataJoinResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataJoinResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SharedDataJoinResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *SharedDataJoinResponse) GetState() *SharedDataSendState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *SharedDataJoinResponse) GetVersions() map[string]uint64 {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *SharedDataJoinResponse) GetNext() string {
+	if x != nil {
+		return x.Next
+	}
+	return ""
+}
+
+type SharedDataGetState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string            `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact      `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Versions     map[string]uint64 `protobuf:"bytes,3,rep,name=versions,proto3" json:"versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	After        string            `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+}
+
+func (x *SharedDataGetState) Reset() {
+	*x = SharedDataGetState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataGetState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataGetState) ProtoMessage() {}
+
+func (x *SharedDataGetState) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataGetState.ProtoReflect.Descriptor instead.
+func (*SharedDataGetState) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SharedDataGetState) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataGetState) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataGetState) GetVersions() map[string]uint64 {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *SharedDataGetState) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+type SharedDataGetStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State *SharedDataSendState `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Next  string               `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+}
+
+func (x *SharedDataGetStateResponse) Reset() {
+	*x = SharedDataGetStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataGetStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataGetStateResponse) ProtoMessage() {}
+
+func (x *SharedDataGetStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataGetStateResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataGetStateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SharedDataGetStateResponse) GetState() *SharedDataSendState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *SharedDataGetStateResponse) GetNext() string {
+	if x != nil {
+		return x.Next
+	}
+	return ""
+}
+
+type SharedDataLeave struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	As           string       `protobuf:"bytes,3,opt,name=as,proto3" json:"as,omitempty"`
+	Seq          uint64       `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *SharedDataLeave) Reset() {
+	*x = SharedDataLeave{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataLeave) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataLeave) ProtoMessage() {}
+
+func (x *SharedDataLeave) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataLeave.ProtoReflect.Descriptor instead.
+func (*SharedDataLeave) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SharedDataLeave) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataLeave) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataLeave) GetAs() string {
+	if x != nil {
+		return x.As
+	}
+	return ""
+}
+
+func (x *SharedDataLeave) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type SharedDataLeaveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataLeaveResponse) Reset() {
+	*x = SharedDataLeaveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataLeaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataLeaveResponse) ProtoMessage() {}
+
+func (x *SharedDataLeaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataLeaveResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataLeaveResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{51}
+}
+
+type SharedDataDefineGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Group        string       `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	Roles        []string     `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	Seq          uint64       `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *SharedDataDefineGroup) Reset() {
+	*x = SharedDataDefineGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataDefineGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataDefineGroup) ProtoMessage() {}
+
+func (x *SharedDataDefineGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataDefineGroup.ProtoReflect.Descriptor instead.
+func (*SharedDataDefineGroup) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SharedDataDefineGroup) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataDefineGroup) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataDefineGroup) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *SharedDataDefineGroup) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *SharedDataDefineGroup) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type SharedDataDefineGroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataDefineGroupResponse) Reset() {
+	*x = SharedDataDefineGroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataDefineGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataDefineGroupResponse) ProtoMessage() {}
+
+func (x *SharedDataDefineGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataDefineGroupResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataDefineGroupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{53}
+}
+
+type SharedDataSetVisibility struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Visibility   string       `protobuf:"bytes,4,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Value        []byte       `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Seq          uint64       `protobuf:"varint,6,opt,name=seq,proto3" json:"seq,omitempty"`
+	Codec        string       `protobuf:"bytes,7,opt,name=codec,proto3" json:"codec,omitempty"`
+	Version      uint64       `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	Path         string       `protobuf:"bytes,9,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *SharedDataSetVisibility) Reset() {
+	*x = SharedDataSetVisibility{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataSetVisibility) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataSetVisibility) ProtoMessage() {}
+
+func (x *SharedDataSetVisibility) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataSetVisibility.ProtoReflect.Descriptor instead.
+func (*SharedDataSetVisibility) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SharedDataSetVisibility) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataSetVisibility) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataSetVisibility) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataSetVisibility) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *SharedDataSetVisibility) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SharedDataSetVisibility) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SharedDataSetVisibility) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *SharedDataSetVisibility) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SharedDataSetVisibility) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type SharedDataSetVisibilityResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataSetVisibilityResponse) Reset() {
+	*x = SharedDataSetVisibilityResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataSetVisibilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataSetVisibilityResponse) ProtoMessage() {}
+
+func (x *SharedDataSetVisibilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataSetVisibilityResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataSetVisibilityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{55}
+}
+
+type SharedDataMerge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value        []byte       `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Seq          uint64       `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+	Codec        string       `protobuf:"bytes,6,opt,name=codec,proto3" json:"codec,omitempty"`
+}
+
+func (x *SharedDataMerge) Reset() {
+	*x = SharedDataMerge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataMerge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataMerge) ProtoMessage() {}
+
+func (x *SharedDataMerge) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataMerge.ProtoReflect.Descriptor instead.
+func (*SharedDataMerge) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SharedDataMerge) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataMerge) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataMerge) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataMerge) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SharedDataMerge) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SharedDataMerge) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+type SharedDataMergeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataMergeResponse) Reset() {
+	*x = SharedDataMergeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataMergeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataMergeResponse) ProtoMessage() {}
+
+func (x *SharedDataMergeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataMergeResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataMergeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{57}
+}
+
+type SharedDataDelete struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Seq          uint64       `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+	Path         string       `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *SharedDataDelete) Reset() {
+	*x = SharedDataDelete{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataDelete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataDelete) ProtoMessage() {}
+
+func (x *SharedDataDelete) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataDelete.ProtoReflect.Descriptor instead.
+func (*SharedDataDelete) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SharedDataDelete) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataDelete) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataDelete) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataDelete) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SharedDataDelete) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type SharedDataDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataDeleteResponse) Reset() {
+	*x = SharedDataDeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataDeleteResponse) ProtoMessage() {}
+
+func (x *SharedDataDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataDeleteResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{59}
+}
+
+type SharedDataDeleteMapKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	MapKey       string       `protobuf:"bytes,4,opt,name=mapKey,proto3" json:"mapKey,omitempty"`
+	Seq          uint64       `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *SharedDataDeleteMapKey) Reset() {
+	*x = SharedDataDeleteMapKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataDeleteMapKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataDeleteMapKey) ProtoMessage() {}
+
+func (x *SharedDataDeleteMapKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataDeleteMapKey.ProtoReflect.Descriptor instead.
+func (*SharedDataDeleteMapKey) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SharedDataDeleteMapKey) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataDeleteMapKey) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataDeleteMapKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataDeleteMapKey) GetMapKey() string {
+	if x != nil {
+		return x.MapKey
+	}
+	return ""
+}
+
+func (x *SharedDataDeleteMapKey) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type SharedDataDeleteMapKeyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataDeleteMapKeyResponse) Reset() {
+	*x = SharedDataDeleteMapKeyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataDeleteMapKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataDeleteMapKeyResponse) ProtoMessage() {}
+
+func (x *SharedDataDeleteMapKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataDeleteMapKeyResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataDeleteMapKeyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{61}
+}
+
+type SharedDataSplice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Index        int64        `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	Count        int64        `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	Values       []byte       `protobuf:"bytes,6,opt,name=values,proto3" json:"values,omitempty"`
+	Codec        string       `protobuf:"bytes,7,opt,name=codec,proto3" json:"codec,omitempty"`
+	Seq          uint64       `protobuf:"varint,8,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *SharedDataSplice) Reset() {
+	*x = SharedDataSplice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataSplice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataSplice) ProtoMessage() {}
+
+func (x *SharedDataSplice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataSplice.ProtoReflect.Descriptor instead.
+func (*SharedDataSplice) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SharedDataSplice) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataSplice) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataSplice) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataSplice) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SharedDataSplice) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SharedDataSplice) GetValues() []byte {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *SharedDataSplice) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *SharedDataSplice) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type SharedDataSpliceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataSpliceResponse) Reset() {
+	*x = SharedDataSpliceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataSpliceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataSpliceResponse) ProtoMessage() {}
+
+func (x *SharedDataSpliceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataSpliceResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataSpliceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{63}
+}
+
+// SharedDataOp is one change in a SharedDataTransaction, op names the
+// change the way the routes do, such as "set" or "changeowner".
+type SharedDataOp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Op         string `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Key        string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	MapKey     string `protobuf:"bytes,3,opt,name=mapKey,proto3" json:"mapKey,omitempty"`
+	Value      []byte `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Codec      string `protobuf:"bytes,5,opt,name=codec,proto3" json:"codec,omitempty"`
+	Owner      string `protobuf:"bytes,6,opt,name=owner,proto3" json:"owner,omitempty"`
+	Visibility string `protobuf:"bytes,7,opt,name=visibility,proto3" json:"visibility,omitempty"`
+}
+
+func (x *SharedDataOp) Reset() {
+	*x = SharedDataOp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataOp) ProtoMessage() {}
+
+func (x *SharedDataOp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataOp.ProtoReflect.Descriptor instead.
+func (*SharedDataOp) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *SharedDataOp) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *SharedDataOp) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataOp) GetMapKey() string {
+	if x != nil {
+		return x.MapKey
+	}
+	return ""
+}
+
+func (x *SharedDataOp) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SharedDataOp) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *SharedDataOp) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SharedDataOp) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+type SharedDataTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string          `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact    `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Ops          []*SharedDataOp `protobuf:"bytes,3,rep,name=ops,proto3" json:"ops,omitempty"`
+	Seq          uint64          `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *SharedDataTransaction) Reset() {
+	*x = SharedDataTransaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataTransaction) ProtoMessage() {}
+
+func (x *SharedDataTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataTransaction.ProtoReflect.Descriptor instead.
+func (*SharedDataTransaction) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SharedDataTransaction) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataTransaction) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataTransaction) GetOps() []*SharedDataOp {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+func (x *SharedDataTransaction) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type SharedDataTransactionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataTransactionResponse) Reset() {
+	*x = SharedDataTransactionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataTransactionResponse) ProtoMessage() {}
+
+func (x *SharedDataTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataTransactionResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{66}
+}
+
+// SharedDataHeartbeat tells the other members we are still here, which
+// renews the leases on the keys we own.
+type SharedDataHeartbeat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+}
+
+func (x *SharedDataHeartbeat) Reset() {
+	*x = SharedDataHeartbeat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataHeartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataHeartbeat) ProtoMessage() {}
+
+func (x *SharedDataHeartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataHeartbeat.ProtoReflect.Descriptor instead.
+func (*SharedDataHeartbeat) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SharedDataHeartbeat) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataHeartbeat) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+type SharedDataHeartbeatResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataHeartbeatResponse) Reset() {
+	*x = SharedDataHeartbeatResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataHeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataHeartbeatResponse) ProtoMessage() {}
+
+func (x *SharedDataHeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataHeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataHeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{68}
+}
+
+// SharedDataReassign is sent by the member that takes over a key when the
+// lease of its owner expires.
+type SharedDataReassign struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Previous     string       `protobuf:"bytes,4,opt,name=previous,proto3" json:"previous,omitempty"`
+	Owner        string       `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	Seq          uint64       `protobuf:"varint,6,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *SharedDataReassign) Reset() {
+	*x = SharedDataReassign{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataReassign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataReassign) ProtoMessage() {}
+
+func (x *SharedDataReassign) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataReassign.ProtoReflect.Descriptor instead.
+func (*SharedDataReassign) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *SharedDataReassign) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataReassign) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataReassign) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataReassign) GetPrevious() string {
+	if x != nil {
+		return x.Previous
+	}
+	return ""
+}
+
+func (x *SharedDataReassign) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SharedDataReassign) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type SharedDataReassignResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataReassignResponse) Reset() {
+	*x = SharedDataReassignResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataReassignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataReassignResponse) ProtoMessage() {}
+
+func (x *SharedDataReassignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataReassignResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataReassignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{70}
+}
+
+type SharedDataRequestOwnership struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *SharedDataRequestOwnership) Reset() {
+	*x = SharedDataRequestOwnership{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataRequestOwnership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataRequestOwnership) ProtoMessage() {}
+
+func (x *SharedDataRequestOwnership) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataRequestOwnership.ProtoReflect.Descriptor instead.
+func (*SharedDataRequestOwnership) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *SharedDataRequestOwnership) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataRequestOwnership) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataRequestOwnership) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type SharedDataRequestOwnershipResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataRequestOwnershipResponse) Reset() {
+	*x = SharedDataRequestOwnershipResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataRequestOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataRequestOwnershipResponse) ProtoMessage() {}
+
+func (x *SharedDataRequestOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataRequestOwnershipResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataRequestOwnershipResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{72}
+}
+
+type SharedDataSetSuccessor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Key          string       `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Successor    string       `protobuf:"bytes,4,opt,name=successor,proto3" json:"successor,omitempty"`
+	Seq          uint64       `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *SharedDataSetSuccessor) Reset() {
+	*x = SharedDataSetSuccessor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataSetSuccessor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataSetSuccessor) ProtoMessage() {}
+
+func (x *SharedDataSetSuccessor) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataSetSuccessor.ProtoReflect.Descriptor instead.
+func (*SharedDataSetSuccessor) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *SharedDataSetSuccessor) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataSetSuccessor) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataSetSuccessor) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataSetSuccessor) GetSuccessor() string {
+	if x != nil {
+		return x.Successor
+	}
+	return ""
+}
+
+func (x *SharedDataSetSuccessor) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type SharedDataSetSuccessorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataSetSuccessorResponse) Reset() {
+	*x = SharedDataSetSuccessorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataSetSuccessorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataSetSuccessorResponse) ProtoMessage() {}
+
+func (x *SharedDataSetSuccessorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataSetSuccessorResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataSetSuccessorResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{74}
+}
+
+// SharedDataHost is sent by the member that takes over hosting the share
+// from previous, who left or whose lease expired.
+type SharedDataHost struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SharedDataId string       `protobuf:"bytes,1,opt,name=sharedDataId,proto3" json:"sharedDataId,omitempty"`
+	Originator   *UserContact `protobuf:"bytes,2,opt,name=originator,proto3" json:"originator,omitempty"`
+	Previous     string       `protobuf:"bytes,3,opt,name=previous,proto3" json:"previous,omitempty"`
+	Seq          uint64       `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *SharedDataHost) Reset() {
+	*x = SharedDataHost{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataHost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataHost) ProtoMessage() {}
+
+func (x *SharedDataHost) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataHost.ProtoReflect.Descriptor instead.
+func (*SharedDataHost) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *SharedDataHost) GetSharedDataId() string {
+	if x != nil {
+		return x.SharedDataId
+	}
+	return ""
+}
+
+func (x *SharedDataHost) GetOriginator() *UserContact {
+	if x != nil {
+		return x.Originator
+	}
+	return nil
+}
+
+func (x *SharedDataHost) GetPrevious() string {
+	if x != nil {
+		return x.Previous
+	}
+	return ""
+}
+
+func (x *SharedDataHost) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+type SharedDataHostResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SharedDataHostResponse) Reset() {
+	*x = SharedDataHostResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataHostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataHostResponse) ProtoMessage() {}
+
+func (x *SharedDataHostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataHostResponse.ProtoReflect.Descriptor instead.
+func (*SharedDataHostResponse) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{76}
+}
+
+// SharedDataStoreSnapshot is how a share is kept by a SharedDataStore, as
+// the state we would send ourselves.
+type SharedDataStoreSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State   *SharedDataSendState `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Creator *UserContact         `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
+	Me      string               `protobuf:"bytes,3,opt,name=me,proto3" json:"me,omitempty"`
+}
+
+func (x *SharedDataStoreSnapshot) Reset() {
+	*x = SharedDataStoreSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataStoreSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataStoreSnapshot) ProtoMessage() {}
+
+func (x *SharedDataStoreSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataStoreSnapshot.ProtoReflect.Descriptor instead.
+func (*SharedDataStoreSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *SharedDataStoreSnapshot) GetState() *SharedDataSendState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *SharedDataStoreSnapshot) GetCreator() *UserContact {
+	if x != nil {
+		return x.Creator
+	}
+	return nil
+}
+
+func (x *SharedDataStoreSnapshot) GetMe() string {
+	if x != nil {
+		return x.Me
+	}
+	return ""
+}
+
+// SharedDataStoreRecord is logged after each change to a key, it holds the
+// whole key as it is after the change and the change's history entry.
+type SharedDataStoreRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key     string                  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Data    *SharedDataData         `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Deleted bool                    `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Entry   *SharedDataHistoryEntry `protobuf:"bytes,4,opt,name=entry,proto3" json:"entry,omitempty"`
+}
+
+func (x *SharedDataStoreRecord) Reset() {
+	*x = SharedDataStoreRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_grapevine_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDataStoreRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDataStoreRecord) ProtoMessage() {}
+
+func (x *SharedDataStoreRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_grapevine_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDataStoreRecord.ProtoReflect.Descriptor instead.
+func (*SharedDataStoreRecord) Descriptor() ([]byte, []int) {
+	return file_proto_grapevine_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *SharedDataStoreRecord) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SharedDataStoreRecord) GetData() *SharedDataData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SharedDataStoreRecord) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *SharedDataStoreRecord) GetEntry() *SharedDataHistoryEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+var File_proto_grapevine_proto protoreflect.FileDescriptor
+
+var file_proto_grapevine_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x65, 0x76, 0x69, 0x6e,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x6d, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1b, 0x0a,
+	0x09, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x09, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x22, 0x80, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x09,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x09, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x63, 0x74, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x7a, 0x0a, 0x06, 0x47, 0x6f, 0x73,
+	0x73, 0x69, 0x70, 0x12, 0x38, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x4f, 0x66, 0x4c, 0x69, 0x66, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x4f, 0x66, 0x4c, 0x69, 0x66, 0x65, 0x12, 0x27, 0x0a,
+	0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x00, 0x52, 0x06,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x42, 0x0d, 0x0a, 0x0b, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70,
+	0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0x36, 0x0a, 0x0d, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x06, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47,
+	0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x06, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x22, 0x37, 0x0a,
+	0x0e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x25, 0x0a, 0x06, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x06,
+	0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x22, 0x19, 0x0a, 0x17, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x1a, 0x0a, 0x18, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x49, 0x6e, 0x76, 0x69, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x0a,
+	0x16, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x4f, 0x77, 0x6e, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x19, 0x0a, 0x17, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x0a,
+	0x16, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x19, 0x0a, 0x17, 0x4c, 0x65, 0x61, 0x76, 0x65,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0xb2, 0x01, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x07, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74,
+	0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x61, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x36, 0x0a, 0x18, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x22,
+	0xd0, 0x03, 0x0a, 0x0f, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x49, 0x64, 0x12,
+	0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x07, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x61, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x40, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12,
+	0x24, 0x0a, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x19, 0x0a, 0x17, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
+	0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x96, 0x01,
+	0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x49, 0x64, 0x12, 0x32,
+	0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x60, 0x0a, 0x18, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x22, 0x62, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x69, 0x6e, 0x76, 0x69, 0x74, 0x65, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74,
+	0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x1a, 0x0a, 0x18,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xab, 0x01, 0x0a, 0x13, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x4a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72,
+	0x12, 0x0e, 0x0a, 0x02, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x61, 0x73,
+	0x12, 0x34, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x22, 0x5c, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x22, 0x60, 0x0a, 0x18, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x22, 0xf0, 0x01, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12,
+	0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03,
+	0x73, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x22, 0x1a, 0x0a, 0x18, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xf5, 0x01, 0x0a, 0x15, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x72, 0x72, 0x61, 0x79, 0x12,
+	0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x22, 0x1f, 0x0a,
+	0x1d, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xf3,
+	0x01, 0x0a, 0x13, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x4d, 0x61, 0x70, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a,
+	0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x73, 0x65, 0x71, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63,
+	0x6f, 0x64, 0x65, 0x63, 0x22, 0x1d, 0x0a, 0x1b, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0xcb, 0x01, 0x0a, 0x0d, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x53, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x22, 0x17, 0x0a, 0x15, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x87, 0x02, 0x0a, 0x17, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65,
+	0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x2a, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x06, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x22, 0x21, 0x0a, 0x1f, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x41, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xd2, 0x01, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x4d, 0x61, 0x70, 0x12, 0x22, 0x0a, 0x0c,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64,
+	0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x22, 0x1a, 0x0a, 0x18,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x4d, 0x61, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xce, 0x01, 0x0a, 0x10, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x12, 0x22, 0x0a,
+	0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49,
+	0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x63, 0x6f, 0x64, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x1a, 0x0a, 0x18, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xbd, 0x01, 0x0a, 0x15, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12,
+	0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12,
+	0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65,
+	0x71, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x1f, 0x0a, 0x1d, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xac, 0x03, 0x0a, 0x0e, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x69, 0x73, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x69, 0x73, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6f,
+	0x72, 0x12, 0x39, 0x0a, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x4b, 0x0a, 0x0c,
+	0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x76, 0x69, 0x73,
+	0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3f, 0x0a, 0x11, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x27, 0x0a, 0x0f, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0x92,
+	0x06, 0x0a, 0x13, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x6e,
+	0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x38,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53,
+	0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x09, 0x6c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65,
+	0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
+	0x73, 0x12, 0x3e, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x12, 0x37, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x2c, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x1a, 0x4e,
+	0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x50,
+	0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x28, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x51, 0x0a, 0x0b, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xe2, 0x02, 0x0a, 0x16, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x66,
+	0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x1d, 0x0a, 0x1b, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc4, 0x02, 0x0a, 0x0e, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x4a, 0x6f, 0x69, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32,
+	0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x61, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x12, 0x3f,
+	0x0a, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x4a, 0x6f, 0x69, 0x6e, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x30, 0x0a, 0x09, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x09, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65,
+	0x72, 0x1a, 0x3b, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x80,
+	0x02, 0x0a, 0x16, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x4a, 0x6f, 0x69,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63,
+	0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x63,
+	0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x47, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x4a, 0x6f, 0x69, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x65, 0x78, 0x74, 0x1a, 0x3b, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x84, 0x02, 0x0a, 0x12, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
+	0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x12, 0x43, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x1a, 0x3b, 0x0a, 0x0d, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x62, 0x0a, 0x1a, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x22, 0x8b, 0x01, 0x0a,
+	0x0f, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x4c, 0x65, 0x61, 0x76, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x61, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x22, 0x19, 0x0a, 0x17, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xad, 0x01, 0x0a, 0x15, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f,
+	0x6c, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x03, 0x73, 0x65, 0x71, 0x22, 0x1f, 0x0a, 0x1d, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x8f, 0x02, 0x0a, 0x17, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x0a,
+	0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x03, 0x73, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x21, 0x0a, 0x1f, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xb9, 0x01, 0x0a, 0x0f,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x12,
+	0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65,
+	0x71, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x22, 0x19, 0x0a, 0x17, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0xa2, 0x01, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03,
+	0x73, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x1a, 0x0a, 0x18, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0xac, 0x01, 0x0a, 0x16, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x12, 0x22,
+	0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
+	0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x70, 0x4b,
+	0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73,
+	0x65, 0x71, 0x22, 0x20, 0x0a, 0x1e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0xe8, 0x01, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x53, 0x70, 0x6c, 0x69, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a,
+	0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x65, 0x71, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x22,
+	0x1a, 0x0a, 0x18, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x70, 0x6c,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xaa, 0x01, 0x0a, 0x0c,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x4f, 0x70, 0x12, 0x0e, 0x0a, 0x02,
+	0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64,
+	0x65, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x69,
+	0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0xa8, 0x01, 0x0a, 0x15, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x03, 0x6f, 0x70,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x4f, 0x70, 0x52, 0x03, 0x6f, 0x70,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03,
+	0x73, 0x65, 0x71, 0x22, 0x1f, 0x0a, 0x1d, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6d, 0x0a, 0x13, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12,
+	0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x6f, 0x72, 0x22, 0x1d, 0x0a, 0x1b, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0xc2, 0x01, 0x0a, 0x12, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x52, 0x65, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a,
+	0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x22, 0x1c, 0x0a, 0x1a, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x1a, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74,
+	0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x24,
+	0x0a, 0x22, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0xb2, 0x01, 0x0a, 0x16, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
+	0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x12,
+	0x22, 0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x22, 0x20, 0x0a, 0x1e, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x96, 0x01, 0x0a, 0x0e,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x22,
+	0x0a, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61,
+	0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55,
+	0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x03, 0x73, 0x65, 0x71, 0x22, 0x18, 0x0a, 0x16, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x89,
+	0x01, 0x0a, 0x17, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x6e, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x07,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6d, 0x65, 0x22, 0xa3, 0x01, 0x0a, 0x15, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x05, 0x65,
+	0x6e, 0x74, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79,
+	0x32, 0xce, 0x03, 0x0a, 0x10, 0x47, 0x72, 0x61, 0x70, 0x65, 0x76, 0x69, 0x6e, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x12,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x6f,
+	0x73, 0x73, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x49,
+	0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x49, 0x6e, 0x76, 0x69, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0f, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1d, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x50, 0x0a, 0x0f, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x68, 0x6f, 0x79, 0x6c, 0x65, 0x31, 0x39, 0x37, 0x34, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x65, 0x76,
+	0x69, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_proto_grapevine_proto_rawDescOnce sync.Once
+	file_proto_grapevine_proto_rawDescData = file_proto_grapevine_proto_rawDesc
+)
+
+func file_proto_grapevine_proto_rawDescGZIP() []byte {
+	file_proto_grapevine_proto_rawDescOnce.Do(func() {
+		file_proto_grapevine_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_grapevine_proto_rawDescData)
+	})
+	return file_proto_grapevine_proto_rawDescData
+}
+
+var file_proto_grapevine_proto_msgTypes = make([]protoimpl.MessageInfo, 88)
+var file_proto_grapevine_proto_goTypes = []interface{}{
+	(*Search)(nil),                             // 0: proto.Search
+	(*SearchResultRequest)(nil),                // 1: proto.SearchResultRequest
+	(*SearchResultResponse)(nil),               // 2: proto.SearchResultResponse
+	(*Gossip)(nil),                             // 3: proto.Gossip
+	(*GossipRequest)(nil),                      // 4: proto.GossipRequest
+	(*GossipResponse)(nil),                     // 5: proto.GossipResponse
+	(*SharedInvitationRequest)(nil),            // 6: proto.SharedInvitationRequest
+	(*SharedInvitationResponse)(nil),           // 7: proto.SharedInvitationResponse
+	(*ChangeDataOwnerRequest)(nil),             // 8: proto.ChangeDataOwnerRequest
+	(*ChangeDataOwnerResponse)(nil),            // 9: proto.ChangeDataOwnerResponse
+	(*ChangeDataRequest)(nil),                  // 10: proto.ChangeDataRequest
+	(*ChangeDataResponse)(nil),                 // 11: proto.ChangeDataResponse
+	(*LeaveSharedDataRequest)(nil),             // 12: proto.LeaveSharedDataRequest
+	(*LeaveSharedDataResponse)(nil),            // 13: proto.LeaveSharedDataResponse
+	(*SharedDataInvite)(nil),                   // 14: proto.SharedDataInvite
+	(*SharedDataInviteResponse)(nil),           // 15: proto.SharedDataInviteResponse
+	(*SharedDataOffer)(nil),                    // 16: proto.SharedDataOffer
+	(*SharedDataOfferResponse)(nil),            // 17: proto.SharedDataOfferResponse
+	(*SharedDataAnswer)(nil),                   // 18: proto.SharedDataAnswer
+	(*SharedDataAnswerResponse)(nil),           // 19: proto.SharedDataAnswerResponse
+	(*SharedDataRevoke)(nil),                   // 20: proto.SharedDataRevoke
+	(*SharedDataRevokeResponse)(nil),           // 21: proto.SharedDataRevokeResponse
+	(*SharedDataJoinToken)(nil),                // 22: proto.SharedDataJoinToken
+	(*SharedDataRedeem)(nil),                   // 23: proto.SharedDataRedeem
+	(*SharedDataRedeemResponse)(nil),           // 24: proto.SharedDataRedeemResponse
+	(*SharedDataCreate)(nil),                   // 25: proto.SharedDataCreate
+	(*SharedDataCreateResponse)(nil),           // 26: proto.SharedDataCreateResponse
+	(*SharedDataCreateArray)(nil),              // 27: proto.SharedDataCreateArray
+	(*SharedDataCreateArrayResponse)(nil),      // 28: proto.SharedDataCreateArrayResponse
+	(*SharedDataCreateMap)(nil),                // 29: proto.SharedDataCreateMap
+	(*SharedDataCreateMapResponse)(nil),        // 30: proto.SharedDataCreateMapResponse
+	(*SharedDataSet)(nil),                      // 31: proto.SharedDataSet
+	(*SharedDataSetResponse)(nil),              // 32: proto.SharedDataSetResponse
+	(*SharedDataCompareAndSet)(nil),            // 33: proto.SharedDataCompareAndSet
+	(*SharedDataCompareAndSetResponse)(nil),    // 34: proto.SharedDataCompareAndSetResponse
+	(*SharedDataSetMap)(nil),                   // 35: proto.SharedDataSetMap
+	(*SharedDataSetMapResponse)(nil),           // 36: proto.SharedDataSetMapResponse
+	(*SharedDataAppend)(nil),                   // 37: proto.SharedDataAppend
+	(*SharedDataAppendResponse)(nil),           // 38: proto.SharedDataAppendResponse
+	(*SharedDataChangeOwner)(nil),              // 39: proto.SharedDataChangeOwner
+	(*SharedDataChangeOwnerResponse)(nil),      // 40: proto.SharedDataChangeOwnerResponse
+	(*SharedDataData)(nil),                     // 41: proto.SharedDataData
+	(*SharedDataGroup)(nil),                    // 42: proto.SharedDataGroup
+	(*SharedDataSendState)(nil),                // 43: proto.SharedDataSendState
+	(*SharedDataHistoryEntry)(nil),             // 44: proto.SharedDataHistoryEntry
+	(*SharedDataSendStateResponse)(nil),        // 45: proto.SharedDataSendStateResponse
+	(*SharedDataJoin)(nil),                     // 46: proto.SharedDataJoin
+	(*SharedDataJoinResponse)(nil),             // 47: proto.SharedDataJoinResponse
+	(*SharedDataGetState)(nil),                 // 48: proto.SharedDataGetState
+	(*SharedDataGetStateResponse)(nil),         // 49: proto.SharedDataGetStateResponse
+	(*SharedDataLeave)(nil),                    // 50: proto.SharedDataLeave
+	(*SharedDataLeaveResponse)(nil),            // 51: proto.SharedDataLeaveResponse
+	(*SharedDataDefineGroup)(nil),              // 52: proto.SharedDataDefineGroup
+	(*SharedDataDefineGroupResponse)(nil),      // 53: proto.SharedDataDefineGroupResponse
+	(*SharedDataSetVisibility)(nil),            // 54: proto.SharedDataSetVisibility
+	(*SharedDataSetVisibilityResponse)(nil),    // 55: proto.SharedDataSetVisibilityResponse
+	(*SharedDataMerge)(nil),                    // 56: proto.SharedDataMerge
+	(*SharedDataMergeResponse)(nil),            // 57: proto.SharedDataMergeResponse
+	(*SharedDataDelete)(nil),                   // 58: proto.SharedDataDelete
+	(*SharedDataDeleteResponse)(nil),           // 59: proto.SharedDataDeleteResponse
+	(*SharedDataDeleteMapKey)(nil),             // 60: proto.SharedDataDeleteMapKey
+	(*SharedDataDeleteMapKeyResponse)(nil),     // 61: proto.SharedDataDeleteMapKeyResponse
+	(*SharedDataSplice)(nil),                   // 62: proto.SharedDataSplice
+	(*SharedDataSpliceResponse)(nil),           // 63: proto.SharedDataSpliceResponse
+	(*SharedDataOp)(nil),                       // 64: proto.SharedDataOp
+	(*SharedDataTransaction)(nil),              // 65: proto.SharedDataTransaction
+	(*SharedDataTransactionResponse)(nil),      // 66: proto.SharedDataTransactionResponse
+	(*SharedDataHeartbeat)(nil),                // 67: proto.SharedDataHeartbeat
+	(*SharedDataHeartbeatResponse)(nil),        // 68: proto.SharedDataHeartbeatResponse
+	(*SharedDataReassign)(nil),                 // 69: proto.SharedDataReassign
+	(*SharedDataReassignResponse)(nil),         // 70: proto.SharedDataReassignResponse
+	(*SharedDataRequestOwnership)(nil),         // 71: proto.SharedDataRequestOwnership
+	(*SharedDataRequestOwnershipResponse)(nil), // 72: proto.SharedDataRequestOwnershipResponse
+	(*SharedDataSetSuccessor)(nil),             // 73: proto.SharedDataSetSuccessor
+	(*SharedDataSetSuccessorResponse)(nil),     // 74: proto.SharedDataSetSuccessorResponse
+	(*SharedDataHost)(nil),                     // 75: proto.SharedDataHost
+	(*SharedDataHostResponse)(nil),             // 76: proto.SharedDataHostResponse
+	(*SharedDataStoreSnapshot)(nil),            // 77: proto.SharedDataStoreSnapshot
+	(*SharedDataStoreRecord)(nil),              // 78: proto.SharedDataStoreRecord
+	nil,                                        // 79: proto.SharedDataOffer.MetadataEntry
+	nil,                                        // 80: proto.SharedDataData.OwnersEntry
+	nil,                                        // 81: proto.SharedDataData.VisibilitiesEntry
+	nil,                                        // 82: proto.SharedDataSendState.DataEntry
+	nil,                                        // 83: proto.SharedDataSendState.ListenersEntry
+	nil,                                        // 84: proto.SharedDataSendState.GroupsEntry
+	nil,                                        // 85: proto.SharedDataJoin.VersionsEntry
+	nil,                                        // 86: proto.SharedDataJoinResponse.VersionsEntry
+	nil,                                        // 87: proto.SharedDataGetState.VersionsEntry
+	(*UserContact)(nil),                        // 88: proto.UserContact
+	(*timestamppb.Timestamp)(nil),              // 89: google.protobuf.Timestamp
+}
+var file_proto_grapevine_proto_depIdxs = []int32{
+	88, // 0: proto.Search.requestor:type_name -> proto.UserContact
+	88, // 1: proto.SearchResultRequest.responder:type_name -> proto.UserContact
+	88, // 2: proto.SearchResultResponse.responder:type_name -> proto.UserContact
+	89, // 3: proto.Gossip.endOfLife:type_name -> google.protobuf.Timestamp
+	0,  // 4: proto.Gossip.search:type_name -> proto.Search
+	3,  // 5: proto.GossipRequest.gossip:type_name -> proto.Gossip
+	3,  // 6: proto.GossipResponse.gossip:type_name -> proto.Gossip
+	88, // 7: proto.SharedDataInvite.creator:type_name -> proto.UserContact
+	88, // 8: proto.SharedDataOffer.originator:type_name -> proto.UserContact
+	88, // 9: proto.SharedDataOffer.creator:type_name -> proto.UserContact
+	79, // 10: proto.SharedDataOffer.metadata:type_name -> proto.SharedDataOffer.MetadataEntry
+	89, // 11: proto.SharedDataOffer.expires:type_name -> google.protobuf.Timestamp
+	88, // 12: proto.SharedDataAnswer.originator:type_name -> proto.UserContact
+	43, // 13: proto.SharedDataAnswerResponse.state:type_name -> proto.SharedDataSendState
+	88, // 14: proto.SharedDataRevoke.originator:type_name -> proto.UserContact
+	88, // 15: proto.SharedDataJoinToken.issuer:type_name -> proto.UserContact
+	89, // 16: proto.SharedDataJoinToken.expires:type_name -> google.protobuf.Timestamp
+	88, // 17: proto.SharedDataRedeem.originator:type_name -> proto.UserContact
+	43, // 18: proto.SharedDataRedeemResponse.state:type_name -> proto.SharedDataSendState
+	88, // 19: proto.SharedDataCreate.originator:type_name -> proto.UserContact
+	88, // 20: proto.SharedDataCreateArray.originator:type_name -> proto.UserContact
+	88, // 21: proto.SharedDataCreateMap.originator:type_name -> proto.UserContact
+	88, // 22: proto.SharedDataSet.originator:type_name -> proto.UserContact
+	88, // 23: proto.SharedDataCompareAndSet.originator:type_name -> proto.UserContact
+	88, // 24: proto.SharedDataCompareAndSet.author:type_name -> proto.UserContact
+	88, // 25: proto.SharedDataSetMap.originator:type_name -> proto.UserContact
+	88, // 26: proto.SharedDataAppend.originator:type_name -> proto.UserContact
+	88, // 27: proto.SharedDataChangeOwner.originator:type_name -> proto.UserContact
+	80, // 28: proto.SharedDataData.owners:type_name -> proto.SharedDataData.OwnersEntry
+	81, // 29: proto.SharedDataData.visibilities:type_name -> proto.SharedDataData.VisibilitiesEntry
+	88, // 30: proto.SharedDataSendState.originator:type_name -> proto.UserContact
+	82, // 31: proto.SharedDataSendState.data:type_name -> proto.SharedDataSendState.DataEntry
+	83, // 32: proto.SharedDataSendState.listeners:type_name -> proto.SharedDataSendState.ListenersEntry
+	84, // 33: proto.SharedDataSendState.groups:type_name -> proto.SharedDataSendState.GroupsEntry
+	44, // 34: proto.SharedDataSendState.history:type_name -> proto.SharedDataHistoryEntry
+	88, // 35: proto.SharedDataSendState.creator:type_name -> proto.UserContact
+	88, // 36: proto.SharedDataHistoryEntry.originator:type_name -> proto.UserContact
+	89, // 37: proto.SharedDataHistoryEntry.time:type_name -> google.protobuf.Timestamp
+	88, // 38: proto.SharedDataJoin.originator:type_name -> proto.UserContact
+	85, // 39: proto.SharedDataJoin.versions:type_name -> proto.SharedDataJoin.VersionsEntry
+	88, // 40: proto.SharedDataJoin.announcer:type_name -> proto.UserContact
+	43, // 41: proto.SharedDataJoinResponse.state:type_name -> proto.SharedDataSendState
+	86, // 42: proto.SharedDataJoinResponse.versions:type_name -> proto.SharedDataJoinResponse.VersionsEntry
+	88, // 43: proto.SharedDataGetState.originator:type_name -> proto.UserContact
+	87, // 44: proto.SharedDataGetState.versions:type_name -> proto.SharedDataGetState.VersionsEntry
+	43, // 45: proto.SharedDataGetStateResponse.state:type_name -> proto.SharedDataSendState
+	88, // 46: proto.SharedDataLeave.originator:type_name -> proto.UserContact
+	88, // 47: proto.SharedDataDefineGroup.originator:type_name -> proto.UserContact
+	88, // 48: proto.SharedDataSetVisibility.originator:type_name -> proto.UserContact
+	88, // 49: proto.SharedDataMerge.originator:type_name -> proto.UserContact
+	88, // 50: proto.SharedDataDelete.originator:type_name -> proto.UserContact
+	88, // 51: proto.SharedDataDeleteMapKey.originator:type_name -> proto.UserContact
+	88, // 52: proto.SharedDataSplice.originator:type_name -> proto.UserContact
+	88, // 53: proto.SharedDataTransaction.originator:type_name -> proto.UserContact
+	64, // 54: proto.SharedDataTransaction.ops:type_name -> proto.SharedDataOp
+	88, // 55: proto.SharedDataHeartbeat.originator:type_name -> proto.UserContact
+	88, // 56: proto.SharedDataReassign.originator:type_name -> proto.UserContact
+	88, // 57: proto.SharedDataRequestOwnership.originator:type_name -> proto.UserContact
+	88, // 58: proto.SharedDataSetSuccessor.originator:type_name -> proto.UserContact
+	88, // 59: proto.SharedDataHost.originator:type_name -> proto.UserContact
+	43, // 60: proto.SharedDataStoreSnapshot.state:type_name -> proto.SharedDataSendState
+	88, // 61: proto.SharedDataStoreSnapshot.creator:type_name -> proto.UserContact
+	41, // 62: proto.SharedDataStoreRecord.data:type_name -> proto.SharedDataData
+	44, // 63: proto.SharedDataStoreRecord.entry:type_name -> proto.SharedDataHistoryEntry
+	41, // 64: proto.SharedDataSendState.DataEntry.value:type_name -> proto.SharedDataData
+	88, // 65: proto.SharedDataSendState.ListenersEntry.value:type_name -> proto.UserContact
+	42, // 66: proto.SharedDataSendState.GroupsEntry.value:type_name -> proto.SharedDataGroup
+	4,  // 67: proto.GrapevineService.Gossip:input_type -> proto.GossipRequest
+	1,  // 68: proto.GrapevineService.SearchResult:input_type -> proto.SearchResultRequest
+	6,  // 69: proto.GrapevineService.SharedInvitation:input_type -> proto.SharedInvitationRequest
+	8,  // 70: proto.GrapevineService.ChangeDataOwner:input_type -> proto.ChangeDataOwnerRequest
+	10, // 71: proto.GrapevineService.ChangeData:input_type -> proto.ChangeDataRequest
+	12, // 72: proto.GrapevineService.LeaveSharedData:input_type -> proto.LeaveSharedDataRequest
+	5,  // 73: proto.GrapevineService.Gossip:output_type -> proto.GossipResponse
+	2,  // 74: proto.GrapevineService.SearchResult:output_type -> proto.SearchResultResponse
+	7,  // 75: proto.GrapevineService.SharedInvitation:output_type -> proto.SharedInvitationResponse
+	9,  // 76: proto.GrapevineService.ChangeDataOwner:output_type -> proto.ChangeDataOwnerResponse
+	11, // 77: proto.GrapevineService.ChangeData:output_type -> proto.ChangeDataResponse
+	13, // 78: proto.GrapevineService.LeaveSharedData:output_type -> proto.LeaveSharedDataResponse
+	73, // [73:79] is the sub-list for method output_type
+	67, // [67:73] is the sub-list for method input_type
+	67, // [67:67] is the sub-list for extension type_name
+	67, // [67:67] is the sub-list for extension extendee
+	0,  // [0:67] is the sub-list for field type_name
+}
+
+func init() { file_proto_grapevine_proto_init() }
+func file_proto_grapevine_proto_init() {
+	if File_proto_grapevine_proto != nil {
+		return
+	}
+	file_proto_common_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_proto_grapevine_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Search); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResultRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResultResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Gossip); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GossipRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GossipResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedInvitationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedInvitationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeDataOwnerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeDataOwnerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeDataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaveSharedDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaveSharedDataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataInvite); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataInviteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataOffer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataOfferResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataAnswerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataRevoke); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataRevokeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataJoinToken); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataRedeem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataRedeemResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataCreate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataCreateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataCreateArray); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataCreateArrayResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataCreateMap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataCreateMapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataCompareAndSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataCompareAndSetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSetMap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSetMapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataAppend); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataAppendResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataChangeOwner); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataChangeOwnerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataGroup); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2126,8 +6379,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchResultRequest); i {
+		file_proto_grapevine_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSendState); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2138,8 +6391,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchResultResponse); i {
+		file_proto_grapevine_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataHistoryEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2150,8 +6403,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Gossip); i {
+		file_proto_grapevine_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSendStateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2162,8 +6415,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GossipRequest); i {
+		file_proto_grapevine_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataJoin); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2174,8 +6427,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GossipResponse); i {
+		file_proto_grapevine_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataJoinResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2186,8 +6439,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedInvitationRequest); i {
+		file_proto_grapevine_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataGetState); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2198,8 +6451,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedInvitationResponse); i {
+		file_proto_grapevine_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataGetStateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2210,8 +6463,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangeDataOwnerRequest); i {
+		file_proto_grapevine_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataLeave); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2222,8 +6475,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangeDataOwnerResponse); i {
+		file_proto_grapevine_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataLeaveResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2234,8 +6487,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangeDataRequest); i {
+		file_proto_grapevine_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataDefineGroup); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2246,8 +6499,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangeDataResponse); i {
+		file_proto_grapevine_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataDefineGroupResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2258,8 +6511,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LeaveSharedDataRequest); i {
+		file_proto_grapevine_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSetVisibility); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2270,8 +6523,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LeaveSharedDataResponse); i {
+		file_proto_grapevine_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSetVisibilityResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2282,8 +6535,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataInvite); i {
+		file_proto_grapevine_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataMerge); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2294,8 +6547,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataInviteResponse); i {
+		file_proto_grapevine_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataMergeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2306,8 +6559,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataCreate); i {
+		file_proto_grapevine_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataDelete); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2318,8 +6571,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataCreateResponse); i {
+		file_proto_grapevine_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataDeleteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2330,8 +6583,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataCreateArray); i {
+		file_proto_grapevine_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataDeleteMapKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2342,8 +6595,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataCreateArrayResponse); i {
+		file_proto_grapevine_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataDeleteMapKeyResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2354,8 +6607,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataCreateMap); i {
+		file_proto_grapevine_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSplice); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2366,8 +6619,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataCreateMapResponse); i {
+		file_proto_grapevine_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSpliceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2378,8 +6631,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataSet); i {
+		file_proto_grapevine_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataOp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2390,8 +6643,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataSetResponse); i {
+		file_proto_grapevine_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataTransaction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2402,8 +6655,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataSetMap); i {
+		file_proto_grapevine_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataTransactionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2414,8 +6667,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataSetMapResponse); i {
+		file_proto_grapevine_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataHeartbeat); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2426,8 +6679,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataAppend); i {
+		file_proto_grapevine_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataHeartbeatResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2438,8 +6691,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataAppendResponse); i {
+		file_proto_grapevine_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataReassign); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2450,8 +6703,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataChangeOwner); i {
+		file_proto_grapevine_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataReassignResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2462,8 +6715,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataChangeOwnerResponse); i {
+		file_proto_grapevine_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataRequestOwnership); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2474,8 +6727,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataData); i {
+		file_proto_grapevine_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataRequestOwnershipResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2486,8 +6739,8 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataSendState); i {
+		file_proto_grapevine_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSetSuccessor); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2498,8 +6751,56 @@ func file_proto_grapevine_proto_init() {
 				return nil
 			}
 		}
-		file_proto_grapevine_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SharedDataSendStateResponse); i {
+		file_proto_grapevine_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataSetSuccessorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataHost); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataHostResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataStoreSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_grapevine_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDataStoreRecord); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2520,7 +6821,7 @@ func file_proto_grapevine_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_grapevine_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   35,
+			NumMessages:   88,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
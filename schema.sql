@@ -6,3 +6,5 @@ CREATE TABLE users ( id uuid PRIMARY KEY, username varchar(255) UNIQUE NOT NULL,
 CREATE TABLE lists ( id uuid PRIMARY KEY, list_type varchar(255) NOT NULL, owner_id uuid NOT NULL, entity_id uuid NOT NULL);
 
 CREATE TABLE user_contacts ( id uuid PRIMARY KEY, ip inet NOT NULL, port bigint NOT NULL, timestamp timestamptz NOT NULL);
+
+CREATE TABLE sessions ( token varchar(64) PRIMARY KEY, account_id uuid NOT NULL, expires timestamptz NOT NULL);
@@ -124,3 +124,55 @@ func TestSocialList(t *testing.T) {
 	}
 
 }
+
+func TestInvitableContacts(t *testing.T) {
+	appCtx := NewTestAppCtx()
+
+	inviter, _, _ := createAccount(t, appCtx)
+	followed, _, _ := createAccount(t, appCtx)
+	stranger, _, _ := createAccount(t, appCtx)
+	blocker, _, _ := createAccount(t, appCtx)
+	for i, accountId := range []common.AccountId{followed, stranger, blocker} {
+		if err := UpdateUserContact(appCtx, accountId, net.ParseIP("192.168.182.1"), int32(2000+i)); err != nil {
+			t.Error(err)
+		}
+	}
+	for _, accountId := range []common.AccountId{followed, blocker} {
+		if err := AddToSocialList(appCtx, inviter, SocialListType_FOLLOWS, accountId); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := AddToSocialList(appCtx, blocker, SocialListType_BLOCKED, inviter); err != nil {
+		t.Error(err)
+	}
+
+	session, err := CreateSession(appCtx, inviter)
+	if err != nil {
+		t.Error(err)
+	}
+	accountId, err := CheckSession(appCtx, session)
+	assert.Nil(t, err)
+	assert.Equal(t, inviter, accountId)
+	_, err = CheckSession(appCtx, "made up")
+	assert.ErrorIs(t, err, ErrInvalidSession)
+
+	// Expired sessions are deleted once another is made
+	_, err = db.Exec(`insert into "sessions"("token", "account_id", "expires") values('expired', $1, now() - interval '1 hour')`, inviter.String())
+	assert.Nil(t, err)
+	_, err = CheckSession(appCtx, "expired")
+	assert.ErrorIs(t, err, ErrInvalidSession)
+	_, err = CreateSession(appCtx, inviter)
+	assert.Nil(t, err)
+	var expired int
+	assert.Nil(t, db.QueryRow(`select count(*) from "sessions" where "token" = 'expired'`).Scan(&expired))
+	assert.Equal(t, 0, expired)
+
+	// Only the account that is followed and hasn't blocked the inviter
+	contacts, err := GetInvitableContacts(appCtx, inviter, []common.AccountId{followed, stranger, blocker})
+	if err != nil {
+		t.Error(err)
+	}
+	if assert.Len(t, contacts, 1) {
+		assert.Equal(t, followed, contacts[0].AccountId)
+	}
+}
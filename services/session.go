@@ -0,0 +1,53 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/hoyle1974/grapevine/common"
+)
+
+// ErrInvalidSession is returned for a session that was never created or
+// has expired, the caller has to Auth again.
+var ErrInvalidSession = errors.New("invalid or expired session")
+
+// sessionDuration is how long a session lasts after Auth.
+const sessionDuration = 24 * time.Hour
+
+// CreateSession returns a token that stands for accountId in calls made
+// after Auth, until it expires.  The sessions that have expired are deleted
+// as new ones are made.
+func CreateSession(appCtx AppCtx, accountId common.AccountId) (string, error) {
+	log := appCtx.Log("CreateSession")
+	log.Printf("Received: %v", accountId)
+
+	if _, err := appCtx.db.Exec(`delete from "sessions" where "expires" <= now()`); err != nil {
+		log.Warn().Err(err).Msg("Could not delete expired sessions")
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+
+	stmt := `insert into "sessions"("token", "account_id", "expires") values($1, $2, $3)`
+	if _, err := appCtx.db.Exec(stmt, token, accountId.String(), time.Now().Add(sessionDuration)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// CheckSession returns the account token was created for.
+func CheckSession(appCtx AppCtx, token string) (common.AccountId, error) {
+	stmt := `select account_id from "sessions" where "token" = $1 and "expires" > now()`
+	row := appCtx.db.QueryRow(stmt, token)
+
+	var id string
+	if err := row.Scan(&id); err != nil {
+		return common.NilAccountId(), ErrInvalidSession
+	}
+	return common.NewAccountId(id), nil
+}
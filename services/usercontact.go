@@ -62,3 +62,38 @@ func GetUserContacts(appCtx AppCtx, accountIDs []common.AccountId) ([]common.Con
 	return contacts, nil
 
 }
+
+// GetInvitableContacts is GetUserContacts for the accounts inviter may
+// invite, those it follows or that follow it, unless they blocked it.
+func GetInvitableContacts(appCtx AppCtx, inviter common.AccountId, accountIDs []common.AccountId) ([]common.Contact, error) {
+	log := appCtx.Log("GetInvitableContacts")
+	log.Printf("Received: %v/%v", inviter, accountIDs)
+
+	stmt := `select c.id, c.ip, c.port from "user_contacts" c where c.id = any($2::uuid[])
+		and exists (select 1 from "lists" l where l.list_type = 'FOLLOWS'
+			and ((l.owner_id = $1 and l.entity_id = c.id) or (l.owner_id = c.id and l.entity_id = $1)))
+		and not exists (select 1 from "lists" b where b.list_type = 'BLOCKED' and b.owner_id = c.id and b.entity_id = $1)`
+
+	params := make([]string, len(accountIDs))
+	for idx, accountId := range accountIDs {
+		params[idx] = accountId.String()
+	}
+
+	rows, err := appCtx.db.Query(stmt, inviter.String(), pq.Array(params))
+	if err != nil {
+		return []common.Contact{}, err
+	}
+
+	contacts := make([]common.Contact, 0)
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var ip string
+		var port int
+
+		rows.Scan(&id, &ip, &port)
+		contacts = append(contacts, common.NewContact(common.NewAccountId(id), net.ParseIP(ip), port))
+	}
+
+	return contacts, nil
+}
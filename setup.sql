@@ -4,6 +4,7 @@ grant all privileges on database grapevine to grapevine;
 
 \c grapevine
 
+DROP TABLE sessions;
 DROP TABLE user_contacts;
 DROP TABLE lists;
 DROP TABLE users;
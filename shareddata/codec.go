@@ -0,0 +1,439 @@
+package shareddata
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Codec turns values into bytes to send to other members and back.  Every
+// value sent says which codec encoded it, so members using different codecs
+// can still understand each other as long as they know all of them.
+type Codec interface {
+	// Id names the codec on the wire.
+	Id() string
+	Encode(value interface{}) ([]byte, error)
+	Decode(data []byte) (interface{}, error)
+}
+
+const (
+	JSONCodecId     = "json"
+	CBORCodecId     = "cbor"
+	ProtobufCodecId = "protobuf"
+	GobCodecId      = "gob"
+)
+
+var codecs = struct {
+	sync.RWMutex
+	byId map[string]Codec
+}{byId: make(map[string]Codec)}
+
+// RegisterCodec makes codec available to decode values, a codec has to be
+// registered on every member that may receive values it encoded.
+func RegisterCodec(codec Codec) {
+	codecs.Lock()
+	defer codecs.Unlock()
+	codecs.byId[codec.Id()] = codec
+}
+
+func codecFor(id string) (Codec, error) {
+	if id == "" {
+		id = GobCodecId // Sent before values said how they were encoded
+	}
+	codecs.RLock()
+	defer codecs.RUnlock()
+	codec, ok := codecs.byId[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown codec %v", ErrWrongType, id)
+	}
+	return codec, nil
+}
+
+// DefaultCodec is the codec a SharedDataManager encodes values with until
+// SetCodec is called.
+var DefaultCodec Codec = JSONCodec{}
+
+func init() {
+	RegisterCodec(JSONCodec{})
+	RegisterCodec(CBORCodec{})
+	RegisterCodec(ProtobufCodec{})
+	RegisterCodec(GobCodec{})
+
+	for _, value := range []interface{}{
+		false, "", 0, int32(0), int64(0), uint(0), uint32(0), uint64(0), float32(0), float64(0), []byte{},
+		[]string{}, []interface{}{}, map[string]string{}, map[string]interface{}{}, map[string][]string{},
+	} {
+		RegisterType(value)
+	}
+	RegisterType(&lwwRegister{})
+	RegisterType(&orSet{})
+	RegisterType(&gCounter{})
+	RegisterType(&pnCounter{})
+	RegisterType(&rgaList{})
+	RegisterType(&orMap{})
+	RegisterType(crdtState{})
+}
+
+var types = struct {
+	sync.RWMutex
+	byName map[string]reflect.Type
+	names  map[reflect.Type]string
+}{byName: make(map[string]reflect.Type), names: make(map[reflect.Type]string)}
+
+// RegisterType lets the JSON and CBOR codecs decode values of the same type
+// as value, rather than the generic maps and slices they would otherwise
+// produce.  Types are named on the wire the way Go prints them, such as
+// "main.Move", use RegisterTypeName to choose the name.
+func RegisterType(value interface{}) {
+	RegisterTypeName(reflect.TypeOf(value).String(), value)
+}
+
+func RegisterTypeName(name string, value interface{}) {
+	types.Lock()
+	defer types.Unlock()
+	t := reflect.TypeOf(value)
+	types.byName[name] = t
+	types.names[t] = name
+
+	// Still needed to decode values sent with gob
+	gob.Register(value)
+}
+
+func typeName(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	t := reflect.TypeOf(value)
+	types.RLock()
+	defer types.RUnlock()
+	if name, ok := types.names[t]; ok {
+		return name
+	}
+	return t.String()
+}
+
+func typeOf(name string) (reflect.Type, bool) {
+	types.RLock()
+	defer types.RUnlock()
+	t, ok := types.byName[name]
+	return t, ok
+}
+
+// typed is how the JSON and CBOR codecs send a value, along with the name
+// of its type so it can be decoded as the same type.
+type typed[T any] struct {
+	Type  string `json:"type" cbor:"type"`
+	Value T      `json:"value" cbor:"value"`
+}
+
+var arrayType = reflect.TypeOf([]interface{}(nil))
+var mapType = reflect.TypeOf(map[string]interface{}(nil))
+
+// typedOf names the type of value, and of everything in it if it is an
+// array or map, so each element is decoded as the type it was.
+func typedOf(value interface{}) typed[interface{}] {
+	switch v := value.(type) {
+	case []interface{}:
+		elems := make([]typed[interface{}], len(v))
+		for i, e := range v {
+			elems[i] = typedOf(e)
+		}
+		return typed[interface{}]{typeName(value), elems}
+	case map[string]interface{}:
+		elems := make(map[string]typed[interface{}], len(v))
+		for k, e := range v {
+			elems[k] = typedOf(e)
+		}
+		return typed[interface{}]{typeName(value), elems}
+	}
+	return typed[interface{}]{typeName(value), value}
+}
+
+func decodeTyped[Raw any](data []byte, unmarshal func([]byte, interface{}) error, raw func(Raw) []byte) (interface{}, error) {
+	var t typed[Raw]
+	if err := unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	if t.Type == "" {
+		return nil, nil
+	}
+
+	rt, ok := typeOf(t.Type)
+	switch {
+	case ok && rt == arrayType:
+		var elems []Raw
+		if err := unmarshal(raw(t.Value), &elems); err != nil {
+			return nil, err
+		}
+		out := make([]interface{}, len(elems))
+		for i, e := range elems {
+			v, err := decodeTyped(raw(e), unmarshal, raw)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = v
+		}
+		return out, nil
+	case ok && rt == mapType:
+		var elems map[string]Raw
+		if err := unmarshal(raw(t.Value), &elems); err != nil {
+			return nil, err
+		}
+		out := make(map[string]interface{}, len(elems))
+		for k, e := range elems {
+			v, err := decodeTyped(raw(e), unmarshal, raw)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = v
+		}
+		return out, nil
+	}
+	if !ok {
+		// A type we don't know, perhaps from another language
+		var value interface{}
+		err := unmarshal(raw(t.Value), &value)
+		return value, err
+	}
+	value := reflect.New(rt)
+	if err := unmarshal(raw(t.Value), value.Interface()); err != nil {
+		return nil, err
+	}
+	return value.Elem().Interface(), nil
+}
+
+// JSONCodec sends values as {"type": name, "value": value}, the elements of
+// arrays and maps are sent the same way.
+type JSONCodec struct{}
+
+func (JSONCodec) Id() string { return JSONCodecId }
+
+func (JSONCodec) Encode(value interface{}) ([]byte, error) {
+	return json.Marshal(typedOf(value))
+}
+
+func (JSONCodec) Decode(data []byte) (interface{}, error) {
+	return decodeTyped(data, json.Unmarshal, func(raw json.RawMessage) []byte { return raw })
+}
+
+// CBORCodec sends values as a CBOR map of type and value.
+type CBORCodec struct{}
+
+func (CBORCodec) Id() string { return CBORCodecId }
+
+func (CBORCodec) Encode(value interface{}) ([]byte, error) {
+	return cbor.Marshal(typedOf(value))
+}
+
+// cborDecoding decodes maps the way the JSON codec does, rather than as
+// map[interface{}]interface{}.
+var cborDecoding, _ = cbor.DecOptions{DefaultMapType: reflect.TypeOf(map[string]interface{}(nil))}.DecMode()
+
+func (CBORCodec) Decode(data []byte) (interface{}, error) {
+	return decodeTyped(data, cborDecoding.Unmarshal, func(raw cbor.RawMessage) []byte { return raw })
+}
+
+// ProtobufCodec sends values as a google.protobuf.Any.  Messages are sent
+// as they are, simple values as the matching wrapper and maps and slices as
+// a google.protobuf.Value, anything else can't be sent.  Messages are
+// decoded using the global protobuf registry.
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) Id() string { return ProtobufCodecId }
+
+func (ProtobufCodec) Encode(value interface{}) ([]byte, error) {
+	var msg proto.Message
+	switch v := value.(type) {
+	case crdtState:
+		return proto.Marshal(&anypb.Any{TypeUrl: crdtTypeUrl, Value: v})
+	case proto.Message:
+		msg = v
+	case string:
+		msg = wrapperspb.String(v)
+	case bool:
+		msg = wrapperspb.Bool(v)
+	case int:
+		msg = wrapperspb.Int64(int64(v))
+	case int32:
+		msg = wrapperspb.Int64(int64(v))
+	case int64:
+		msg = wrapperspb.Int64(v)
+	case uint:
+		msg = wrapperspb.UInt64(uint64(v))
+	case uint32:
+		msg = wrapperspb.UInt64(uint64(v))
+	case uint64:
+		msg = wrapperspb.UInt64(v)
+	case float32:
+		msg = wrapperspb.Double(float64(v))
+	case float64:
+		msg = wrapperspb.Double(v)
+	case []byte:
+		msg = wrapperspb.Bytes(v)
+	default:
+		s, err := structpb.NewValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: the protobuf codec can't send a %T", ErrWrongType, value)
+		}
+		msg = s
+	}
+
+	a, err := anypb.New(msg)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(a)
+}
+
+func (ProtobufCodec) Decode(data []byte) (interface{}, error) {
+	var a anypb.Any
+	if err := proto.Unmarshal(data, &a); err != nil {
+		return nil, err
+	}
+	if a.TypeUrl == crdtTypeUrl {
+		return crdtState(a.Value), nil
+	}
+	msg, err := a.UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := msg.(type) {
+	case *wrapperspb.StringValue:
+		return v.Value, nil
+	case *wrapperspb.BoolValue:
+		return v.Value, nil
+	case *wrapperspb.Int64Value:
+		return v.Value, nil
+	case *wrapperspb.UInt64Value:
+		return v.Value, nil
+	case *wrapperspb.DoubleValue:
+		return v.Value, nil
+	case *wrapperspb.BytesValue:
+		return v.Value, nil
+	case *structpb.Value:
+		return v.AsInterface(), nil
+	}
+	return msg, nil
+}
+
+type ValueHolder struct {
+	Value interface{}
+}
+
+func init() {
+	gob.Register(ValueHolder{})
+	gob.Register(map[string][]string{})
+}
+
+func toBytes(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(ValueHolder{value}); err != nil {
+		return nil, fmt.Errorf("toBytes: (%v) : %w", value, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func fromBytes(value []byte) (interface{}, error) {
+	if len(value) == 0 {
+		return nil, nil // A value we aren't allowed to see
+	}
+	buf := bytes.NewBuffer(value)
+	dec := gob.NewDecoder(buf)
+
+	var out ValueHolder
+	if err := dec.Decode(&out); err != nil {
+		return nil, fmt.Errorf("fromBytes: %w", err)
+	}
+
+	return out.Value, nil
+}
+
+// GobCodec is how values were sent before codecs could be chosen, it only
+// works between Go members and needs gob.Register, or RegisterType, for
+// every type sent.
+type GobCodec struct{}
+
+func (GobCodec) Id() string { return GobCodecId }
+
+func (GobCodec) Encode(value interface{}) ([]byte, error) {
+	return toBytes(value)
+}
+
+func (GobCodec) Decode(data []byte) (interface{}, error) {
+	return fromBytes(data)
+}
+
+// crdtState is a crdt encoded with gob, which gives back the values in it as
+// the types they were, where JSON would turn an int into a float64.  Every
+// codec sends it as bytes, so whichever codec sent a crdt every member ends
+// up with the same one.
+type crdtState []byte
+
+const crdtTypeUrl = "grapevine/crdt"
+
+// encode encodes value with codec.
+func encode(codec Codec, value interface{}) ([]byte, error) {
+	if c, ok := value.(crdt); ok {
+		state, err := toBytes(c)
+		if err != nil {
+			return nil, err
+		}
+		value = crdtState(state)
+	}
+	b, err := codec.Encode(value)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %T with %v: %w", value, codec.Id(), err)
+	}
+	return b, nil
+}
+
+// decode decodes data sent with the codec named codecId, as a value that
+// isn't a crdt.
+func decode(codecId string, data []byte) (interface{}, error) {
+	value, err := decodeCRDT(codecId, data)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := value.(crdt); ok {
+		return nil, fmt.Errorf("%w: a %v where a value was expected", ErrWrongType, c.kind())
+	}
+	return value, nil
+}
+
+// decodeCRDT decodes data like decode, but also gives back a crdt.  Only
+// creating a key, merging into one and the state of keys may carry one.
+func decodeCRDT(codecId string, data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil // A value we aren't allowed to see
+	}
+	codec, err := codecFor(codecId)
+	if err != nil {
+		return nil, err
+	}
+	value, err := codec.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding with %v: %w", codec.Id(), err)
+	}
+	if state, ok := value.(crdtState); ok {
+		if value, err = fromBytes(state); err != nil {
+			return nil, err
+		}
+	}
+	if c, ok := value.(crdt); ok {
+		if err := wellFormed(c); err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,567 @@
+package shareddata
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// CRDTKind selects the conflict-free replicated data type backing a key
+// created with CreateCRDT.  Changes to these keys are merged rather than
+// overwritten, so members can change them concurrently and still end up with
+// the same value.
+type CRDTKind int
+
+const (
+	// LWWRegister holds a single value, the latest Set wins.  Get returns the value.
+	LWWRegister CRDTKind = iota + 1
+	// ORSet is a set changed with AddToSet and RemoveFromSet, an add wins
+	// over a concurrent remove.  Get returns a []interface{}.
+	ORSet
+	// GCounter is a counter that can only be incremented.  Get returns an int64.
+	GCounter
+	// PNCounter is a counter that can be incremented and decremented.  Get returns an int64.
+	PNCounter
+	// RGAList is a list changed with Append.  Get returns a []interface{}.
+	RGAList
+	// ORMap is a map of last writer wins values changed with SetMap.  Get
+	// returns a map[string]interface{}.
+	ORMap
+)
+
+func (k CRDTKind) String() string {
+	switch k {
+	case LWWRegister:
+		return "lww-register"
+	case ORSet:
+		return "or-set"
+	case GCounter:
+		return "g-counter"
+	case PNCounter:
+		return "pn-counter"
+	case RGAList:
+		return "rga-list"
+	case ORMap:
+		return "or-map"
+	}
+	return "unknown"
+}
+
+type crdt interface {
+	kind() CRDTKind
+	value() interface{}
+	merge(other crdt) error
+	// latest is the newest stamp in the crdt, the clock is moved past it so
+	// later changes win.
+	latest() stamp
+}
+
+func newCRDT(kind CRDTKind) (crdt, error) {
+	switch kind {
+	case LWWRegister:
+		return &lwwRegister{}, nil
+	case ORSet:
+		return &orSet{Entries: make(map[string]*orSetEntry), Removed: make(map[string]bool)}, nil
+	case GCounter:
+		return &gCounter{Counts: make(map[string]uint64)}, nil
+	case PNCounter:
+		return &pnCounter{P: gCounter{Counts: make(map[string]uint64)}, N: gCounter{Counts: make(map[string]uint64)}}, nil
+	case RGAList:
+		return &rgaList{Elems: make(map[string]*rgaElem)}, nil
+	case ORMap:
+		return &orMap{Entries: make(map[string]*orMapEntry), Removed: make(map[string]bool)}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown crdt kind %d", ErrWrongType, kind)
+}
+
+// wellFormed checks c, sent by another member, has what changing it or
+// merging it needs, a malformed one is refused rather than panicking later.
+func wellFormed(c crdt) error {
+	ok := true
+	switch c := c.(type) {
+	case *lwwRegister:
+		ok = c != nil
+	case *orSet:
+		ok = c != nil && c.Entries != nil && c.Removed != nil
+		for _, e := range c.Entries {
+			ok = ok && e != nil && e.Tags != nil
+		}
+	case *gCounter:
+		ok = c != nil && c.Counts != nil
+	case *pnCounter:
+		ok = c != nil && c.P.Counts != nil && c.N.Counts != nil
+	case *rgaList:
+		ok = c != nil && c.Elems != nil
+		for _, e := range c.Elems {
+			ok = ok && e != nil
+		}
+	case *orMap:
+		ok = c != nil && c.Entries != nil && c.Removed != nil
+		for _, e := range c.Entries {
+			ok = ok && e != nil && e.Tags != nil
+		}
+	}
+	if !ok {
+		return fmt.Errorf("%w: a malformed %v", ErrWrongType, c.kind())
+	}
+	return nil
+}
+
+func mismatch(c crdt, other crdt) error {
+	return fmt.Errorf("%w: can't merge a %v into a %v", ErrWrongType, other.kind(), c.kind())
+}
+
+// stamp is a hybrid logical clock timestamp, Node breaks ties between
+// members so every stamp is unique.
+type stamp struct {
+	Wall    int64
+	Logical uint32
+	Node    string
+}
+
+func (s stamp) after(o stamp) bool {
+	if s.Wall != o.Wall {
+		return s.Wall > o.Wall
+	}
+	if s.Logical != o.Logical {
+		return s.Logical > o.Logical
+	}
+	return s.Node > o.Node
+}
+
+func (s stamp) String() string {
+	if s == (stamp{}) {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d.%s", s.Wall, s.Logical, s.Node)
+}
+
+func maxStamp(a stamp, b stamp) stamp {
+	if b.after(a) {
+		return b
+	}
+	return a
+}
+
+// hlc is a hybrid logical clock, it follows wall time but never goes
+// backwards or falls behind a stamp it has seen.
+type hlc struct {
+	last stamp
+}
+
+func (c *hlc) now(node string) stamp {
+	wall := time.Now().UnixNano()
+	if wall > c.last.Wall {
+		c.last = stamp{Wall: wall}
+	} else {
+		c.last.Logical++
+	}
+	c.last.Node = node
+	return c.last
+}
+
+func (c *hlc) observe(s stamp) {
+	if s.Wall > c.last.Wall || (s.Wall == c.last.Wall && s.Logical > c.last.Logical) {
+		c.last.Wall, c.last.Logical = s.Wall, s.Logical
+	}
+}
+
+type lwwRegister struct {
+	Value interface{}
+	Stamp stamp
+}
+
+func (r *lwwRegister) kind() CRDTKind     { return LWWRegister }
+func (r *lwwRegister) value() interface{} { return r.Value }
+func (r *lwwRegister) latest() stamp      { return r.Stamp }
+
+func (r *lwwRegister) set(value interface{}, at stamp) {
+	if at.after(r.Stamp) {
+		r.Value, r.Stamp = value, at
+	}
+}
+
+func (r *lwwRegister) merge(other crdt) error {
+	o, ok := other.(*lwwRegister)
+	if !ok {
+		return mismatch(r, other)
+	}
+	r.set(o.Value, o.Stamp)
+	return nil
+}
+
+// valueKey identifies equal values in an orSet.
+func valueKey(value interface{}) string {
+	return fmt.Sprintf("%T:%#v", value, value)
+}
+
+type orSetEntry struct {
+	Value interface{}
+	Tags  map[string]bool
+}
+
+// orSet is an observed-remove set, every add is tagged and a remove only
+// removes the tags it has seen.
+type orSet struct {
+	Entries map[string]*orSetEntry
+	Removed map[string]bool
+	Latest  stamp
+}
+
+func (s *orSet) kind() CRDTKind { return ORSet }
+func (s *orSet) latest() stamp  { return s.Latest }
+
+func (s *orSet) add(value interface{}, at stamp) {
+	k := valueKey(value)
+	e, ok := s.Entries[k]
+	if !ok {
+		e = &orSetEntry{Value: value, Tags: make(map[string]bool)}
+		s.Entries[k] = e
+	}
+	e.Tags[at.String()] = true
+	s.Latest = maxStamp(s.Latest, at)
+}
+
+func (s *orSet) remove(value interface{}) {
+	if e, ok := s.Entries[valueKey(value)]; ok {
+		for tag := range e.Tags {
+			s.Removed[tag] = true
+		}
+	}
+}
+
+func (s *orSet) contains(e *orSetEntry) bool {
+	for tag := range e.Tags {
+		if !s.Removed[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *orSet) value() interface{} {
+	keys := make([]string, 0, len(s.Entries))
+	for k, e := range s.Entries {
+		if s.contains(e) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	values := make([]interface{}, len(keys))
+	for i, k := range keys {
+		values[i] = s.Entries[k].Value
+	}
+	return values
+}
+
+func (s *orSet) merge(other crdt) error {
+	o, ok := other.(*orSet)
+	if !ok {
+		return mismatch(s, other)
+	}
+	for k, oe := range o.Entries {
+		e, ok := s.Entries[k]
+		if !ok {
+			e = &orSetEntry{Value: oe.Value, Tags: make(map[string]bool)}
+			s.Entries[k] = e
+		}
+		for tag := range oe.Tags {
+			e.Tags[tag] = true
+		}
+	}
+	for tag := range o.Removed {
+		s.Removed[tag] = true
+	}
+	s.Latest = maxStamp(s.Latest, o.Latest)
+	return nil
+}
+
+// gCounter keeps a count per member, the value is their sum.
+type gCounter struct {
+	Counts map[string]uint64
+}
+
+func (c *gCounter) kind() CRDTKind { return GCounter }
+func (c *gCounter) latest() stamp  { return stamp{} }
+
+func (c *gCounter) increment(node string, n uint64) {
+	c.Counts[node] += n
+}
+
+func (c *gCounter) sum() int64 {
+	var total uint64
+	for _, n := range c.Counts {
+		total += n
+	}
+	return int64(total)
+}
+
+func (c *gCounter) value() interface{} {
+	return c.sum()
+}
+
+func (c *gCounter) merge(other crdt) error {
+	o, ok := other.(*gCounter)
+	if !ok {
+		return mismatch(c, other)
+	}
+	c.mergeCounts(o)
+	return nil
+}
+
+func (c *gCounter) mergeCounts(o *gCounter) {
+	for node, n := range o.Counts {
+		if n > c.Counts[node] {
+			c.Counts[node] = n
+		}
+	}
+}
+
+// pnCounter counts increments and decrements separately.
+type pnCounter struct {
+	P gCounter
+	N gCounter
+}
+
+func (c *pnCounter) kind() CRDTKind { return PNCounter }
+func (c *pnCounter) latest() stamp  { return stamp{} }
+
+func (c *pnCounter) increment(node string, delta int64) {
+	if delta < 0 {
+		c.N.increment(node, uint64(-delta))
+	} else {
+		c.P.increment(node, uint64(delta))
+	}
+}
+
+func (c *pnCounter) value() interface{} {
+	return c.P.sum() - c.N.sum()
+}
+
+func (c *pnCounter) merge(other crdt) error {
+	o, ok := other.(*pnCounter)
+	if !ok {
+		return mismatch(c, other)
+	}
+	c.P.mergeCounts(&o.P)
+	c.N.mergeCounts(&o.N)
+	return nil
+}
+
+// rgaElem is an element of a replicated growable array, it is placed
+// after the element After, newer elements first when several are.
+type rgaElem struct {
+	ID      stamp
+	After   stamp
+	Value   interface{}
+	Deleted bool
+}
+
+type rgaList struct {
+	Elems map[string]*rgaElem
+}
+
+func (l *rgaList) kind() CRDTKind { return RGAList }
+
+func (l *rgaList) latest() stamp {
+	var latest stamp
+	for _, e := range l.Elems {
+		latest = maxStamp(latest, e.ID)
+	}
+	return latest
+}
+
+// order returns every element, deleted ones included, in list order.
+func (l *rgaList) order() []*rgaElem {
+	children := make(map[string][]*rgaElem)
+	for _, e := range l.Elems {
+		after := e.After.String()
+		children[after] = append(children[after], e)
+	}
+	for _, c := range children {
+		sort.Slice(c, func(i, j int) bool { return c[i].ID.after(c[j].ID) })
+	}
+
+	order := make([]*rgaElem, 0, len(l.Elems))
+	var walk func(id string)
+	walk = func(id string) {
+		for _, e := range children[id] {
+			order = append(order, e)
+			walk(e.ID.String())
+		}
+	}
+	walk("")
+	return order
+}
+
+func (l *rgaList) insertAfter(after stamp, value interface{}, at stamp) {
+	l.Elems[at.String()] = &rgaElem{ID: at, After: after, Value: value}
+}
+
+func (l *rgaList) append(value interface{}, at stamp) {
+	var last stamp
+	if order := l.order(); len(order) > 0 {
+		last = order[len(order)-1].ID
+	}
+	l.insertAfter(last, value, at)
+}
+
+// splice deletes count elements from index, not counting deleted ones, and
+// inserts values in their place.  It returns the deleted values.
+func (l *rgaList) splice(index int, count int, values []interface{}, now func() stamp) ([]interface{}, error) {
+	var visible []*rgaElem
+	for _, e := range l.order() {
+		if !e.Deleted {
+			visible = append(visible, e)
+		}
+	}
+	if index < 0 || count < 0 || index+count > len(visible) {
+		return nil, fmt.Errorf("%w: %d+%d of %d", ErrOutOfRange, index, count, len(visible))
+	}
+
+	removed := make([]interface{}, 0, count)
+	for _, e := range visible[index : index+count] {
+		e.Deleted = true
+		removed = append(removed, e.Value)
+	}
+	var after stamp
+	if index > 0 {
+		after = visible[index-1].ID
+	}
+	for _, value := range values {
+		at := now()
+		l.insertAfter(after, value, at)
+		after = at
+	}
+	return removed, nil
+}
+
+func (l *rgaList) value() interface{} {
+	values := make([]interface{}, 0, len(l.Elems))
+	for _, e := range l.order() {
+		if !e.Deleted {
+			values = append(values, e.Value)
+		}
+	}
+	return values
+}
+
+func (l *rgaList) merge(other crdt) error {
+	o, ok := other.(*rgaList)
+	if !ok {
+		return mismatch(l, other)
+	}
+	for id, oe := range o.Elems {
+		e, ok := l.Elems[id]
+		if !ok {
+			dup := *oe
+			l.Elems[id] = &dup
+			continue
+		}
+		e.Deleted = e.Deleted || oe.Deleted
+	}
+	return nil
+}
+
+type orMapEntry struct {
+	Register lwwRegister
+	Tags     map[string]bool
+}
+
+// orMap is an observed-remove map of last writer wins registers.
+type orMap struct {
+	Entries map[string]*orMapEntry
+	Removed map[string]bool
+}
+
+func (m *orMap) kind() CRDTKind { return ORMap }
+
+func (m *orMap) latest() stamp {
+	var latest stamp
+	for _, e := range m.Entries {
+		latest = maxStamp(latest, e.Register.Stamp)
+	}
+	return latest
+}
+
+func (m *orMap) set(key string, value interface{}, at stamp) {
+	e, ok := m.Entries[key]
+	if !ok {
+		e = &orMapEntry{Tags: make(map[string]bool)}
+		m.Entries[key] = e
+	}
+	e.Register.set(value, at)
+	e.Tags[at.String()] = true
+}
+
+func (m *orMap) remove(key string) {
+	if e, ok := m.Entries[key]; ok {
+		for tag := range e.Tags {
+			m.Removed[tag] = true
+		}
+	}
+}
+
+func (m *orMap) contains(e *orMapEntry) bool {
+	for tag := range e.Tags {
+		if !m.Removed[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *orMap) get(key string) interface{} {
+	if e, ok := m.Entries[key]; ok && m.contains(e) {
+		return e.Register.Value
+	}
+	return nil
+}
+
+func (m *orMap) value() interface{} {
+	values := make(map[string]interface{})
+	for k, e := range m.Entries {
+		if m.contains(e) {
+			values[k] = e.Register.Value
+		}
+	}
+	return values
+}
+
+func (m *orMap) merge(other crdt) error {
+	o, ok := other.(*orMap)
+	if !ok {
+		return mismatch(m, other)
+	}
+	for k, oe := range o.Entries {
+		e, ok := m.Entries[k]
+		if !ok {
+			e = &orMapEntry{Tags: make(map[string]bool)}
+			m.Entries[k] = e
+		}
+		e.Register.set(oe.Register.Value, oe.Register.Stamp)
+		for tag := range oe.Tags {
+			e.Tags[tag] = true
+		}
+	}
+	for tag := range o.Removed {
+		m.Removed[tag] = true
+	}
+	return nil
+}
+
+// ownCopy returns value, or if it is a crdt, a copy of it that is safe to
+// change.  Decoded crdts can be missing their empty maps.
+func ownCopy(value interface{}) interface{} {
+	c, ok := value.(crdt)
+	if !ok {
+		return value
+	}
+	own, err := newCRDT(c.kind())
+	if err != nil {
+		return value
+	}
+	own.merge(c)
+	return own
+}
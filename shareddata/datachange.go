@@ -0,0 +1,227 @@
+package shareddata
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/hoyle1974/grapevine/common"
+)
+
+type ChangeKind int
+
+const (
+	CreateChange ChangeKind = iota
+	SetChange
+	SetMapChange
+	AppendChange
+	OwnerChange
+	VisibilityChange
+	MergeChange
+	DeleteChange
+	DeleteMapChange
+	SpliceChange
+	SuccessorChange
+	OwnershipRequest
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case CreateChange:
+		return "create"
+	case SetChange:
+		return "set"
+	case SetMapChange:
+		return "setmap"
+	case AppendChange:
+		return "append"
+	case OwnerChange:
+		return "changeowner"
+	case VisibilityChange:
+		return "setvisibility"
+	case MergeChange:
+		return "merge"
+	case DeleteChange:
+		return "delete"
+	case DeleteMapChange:
+		return "deletemapkey"
+	case SpliceChange:
+		return "splice"
+	case SuccessorChange:
+		return "setsuccessor"
+	case OwnershipRequest:
+		return "requestownership"
+	}
+	return "unknown"
+}
+
+// DataChange describes a single change to a key of a SharedData.  For
+// SetMapChange the values are those of MapKey, for AppendChange NewValue is
+// the appended element and for OwnerChange and VisibilityChange the values
+// are the old and new owners or visibilities.  For MergeChange, made to keys
+// created with CreateCRDT, the values are the whole old and new values.
+// DeleteChange and DeleteMapChange only have the deleted OldValue, and for
+// SpliceChange OldValue and NewValue are the elements removed and inserted
+// at Index.  SuccessorChange has the old and new successors.  Version is the
+// key's version after the change and Visibility who could see the key when
+// it changed.
+//
+// Changes made with SetPath, AppendPath, DeletePath, SetPathOwner and
+// SetPathVisibility have the Path below Key they were made at, their values
+// are those of that part and Visibility is who could see it.
+//
+// OwnershipRequest isn't a change, it tells the owner of Key that the role
+// NewValue would like to own it, see GrantOwnership.
+type DataChange struct {
+	Key        string
+	Path       string
+	MapKey     string
+	Index      int
+	OldValue   interface{}
+	NewValue   interface{}
+	Originator common.Contact
+	Kind       ChangeKind
+	Version    uint64
+	Visibility string
+}
+
+// changeListeners delivers changes to subscribers in the order they happened.
+// Delivery happens on its own goroutine so callbacks are free to read or
+// modify the SharedData that notified them.
+type changeListeners struct {
+	lock        sync.Mutex
+	nextId      int
+	callbacks   map[int]func(DataChange)
+	pending     []pendingChange
+	dispatching bool
+	held        []DataChange
+	holding     bool
+	journals    []func(DataChange)
+}
+
+// pendingChange is a change waiting to be delivered, only the callbacks
+// that were there when it was made, whose ids are below listeners, hear of
+// it.
+type pendingChange struct {
+	DataChange
+	listeners int
+}
+
+func (l *changeListeners) add(cb func(DataChange)) func() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.callbacks == nil {
+		l.callbacks = make(map[int]func(DataChange))
+	}
+	id := l.nextId
+	l.nextId++
+	l.callbacks[id] = cb
+
+	return func() {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		delete(l.callbacks, id)
+	}
+}
+
+func (l *changeListeners) notify(change DataChange) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.holding {
+		l.held = append(l.held, change)
+		return
+	}
+	l.queue(change)
+}
+
+// addJournal has journal told about every change as soon as it is made,
+// rather than later on another goroutine.
+func (l *changeListeners) addJournal(journal func(DataChange)) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.journals = append(l.journals, journal)
+}
+
+func (l *changeListeners) queue(changes ...DataChange) {
+	for _, change := range changes {
+		for _, journal := range l.journals {
+			journal(change)
+		}
+	}
+	l.deliver(changes...)
+}
+
+// tell lets the callbacks know about something that isn't a change, so
+// there is nothing to journal or hold back.
+func (l *changeListeners) tell(change DataChange) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.deliver(change)
+}
+
+func (l *changeListeners) deliver(changes ...DataChange) {
+	if len(l.callbacks) == 0 {
+		return
+	}
+	for _, change := range changes {
+		l.pending = append(l.pending, pendingChange{change, l.nextId})
+	}
+	if !l.dispatching && len(l.pending) > 0 {
+		l.dispatching = true
+		go l.dispatch()
+	}
+}
+
+// hold keeps changes back until release delivers them or drop forgets them,
+// so nobody hears about a transaction that is rolled back.
+func (l *changeListeners) hold() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.holding = true
+}
+
+func (l *changeListeners) release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.holding = false
+	l.queue(l.held...)
+	l.held = nil
+}
+
+func (l *changeListeners) drop() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.holding = false
+	l.held = nil
+}
+
+func (l *changeListeners) dispatch() {
+	for {
+		l.lock.Lock()
+		if len(l.pending) == 0 {
+			l.dispatching = false
+			l.lock.Unlock()
+			return
+		}
+		change := l.pending[0]
+		l.pending = l.pending[1:]
+
+		ids := make([]int, 0, len(l.callbacks))
+		for id := range l.callbacks {
+			if id < change.listeners {
+				ids = append(ids, id)
+			}
+		}
+		sort.Ints(ids)
+		callbacks := make([]func(DataChange), 0, len(ids))
+		for _, id := range ids {
+			callbacks = append(callbacks, l.callbacks[id])
+		}
+		l.lock.Unlock()
+
+		for _, cb := range callbacks {
+			cb(change.DataChange)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package shareddata
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hoyle1974/grapevine/client"
+	"github.com/hoyle1974/grapevine/common"
+	pb "github.com/hoyle1974/grapevine/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Every change a member makes is numbered, separately for each of the other
+// members it is sent to.  The sender keeps resending a change until it is
+// acknowledged and the receiver applies them in the order they were made, no
+// matter how they arrive.
+
+const retryInterval = time.Millisecond * 100
+const maxRetryInterval = time.Second * 5
+
+type envelope struct {
+	uri  string
+	msg  proto.Message
+	resp proto.Message
+	done bool
+	err  error
+}
+
+// outbox holds the changes that haven't been acknowledged by one member yet.
+type outbox struct {
+	lock     sync.Mutex
+	log      common.CallCtx
+	cache    client.GrapevineClientCache
+	to       common.Contact
+	nextSeq  uint64
+	pending  []*envelope
+	retrying bool
+	closed   bool
+	refused  func(msg proto.Message) // Told about each change that was refused
+}
+
+func newOutbox(log common.CallCtx, cache client.GrapevineClientCache, to common.Contact, refused func(msg proto.Message)) *outbox {
+	return &outbox{log: log, cache: cache, to: to, nextSeq: 1, refused: refused}
+}
+
+// queue numbers msg and adds it to the changes waiting to be sent.
+func (o *outbox) queue(uri string, msg proto.Message, resp proto.Message) *envelope {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	e := &envelope{uri: uri, msg: withSeq(msg, o.nextSeq), resp: resp}
+	o.nextSeq++
+	if o.closed {
+		e.done = true
+	} else {
+		o.pending = append(o.pending, e)
+	}
+	return e
+}
+
+// send tries to deliver e, and anything queued before it.  If it can't be
+// delivered now it is kept, along with everything queued after it, and
+// retried in the background until it is or the outbox is closed.
+func (o *outbox) send(e *envelope) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if e.done {
+		return e.err
+	}
+	if o.retrying {
+		return ErrQueued
+	}
+
+	err := o.flush()
+	if e.done {
+		return e.err
+	}
+	o.retrying = true
+	go o.retry()
+	return err
+}
+
+// flush posts the pending changes in order, stopping at the first one that
+// doesn't arrive.  Changes that arrive but are refused aren't retried, the
+// outbox is told about them once the rest have been posted.
+func (o *outbox) flush() error {
+	var refused []proto.Message
+	defer func() {
+		for _, msg := range refused {
+			go o.refused(msg)
+		}
+	}()
+
+	for len(o.pending) > 0 {
+		e := o.pending[0]
+		err := o.cache.POST(o.to.Address, e.uri, e.msg, e.resp.ProtoReflect().New().Interface())
+		if err != nil && !isRefused(err) {
+			return err
+		}
+		if err != nil {
+			o.log.Warn().Err(err).Msgf("%v refused %v", o.to, e.uri)
+			err = refusal(err)
+			refused = append(refused, e.msg)
+		}
+		e.done, e.err = true, err
+		o.pending = o.pending[1:]
+	}
+	return nil
+}
+
+func (o *outbox) retry() {
+	interval := retryInterval
+	for {
+		time.Sleep(interval)
+
+		o.lock.Lock()
+		if o.closed {
+			o.lock.Unlock()
+			return
+		}
+		err := o.flush()
+		if err == nil {
+			o.retrying = false
+			o.lock.Unlock()
+			return
+		}
+		o.log.Warn().Err(err).Msgf("Still can't reach %v, %d changes waiting", o.to, len(o.pending))
+		o.lock.Unlock()
+
+		if interval < maxRetryInterval {
+			interval *= 2
+		}
+	}
+}
+
+// close drops anything not yet delivered, there is no one left to deliver
+// it to, and stops retrying.
+func (o *outbox) close() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.closed = true
+	for _, e := range o.pending {
+		e.done = true
+	}
+	o.pending = nil
+}
+
+// isRefused returns true if err means the request arrived but was rejected,
+// so sending it again won't help.
+func isRefused(err error) bool {
+	var serr *client.StatusError
+	return errors.As(err, &serr) && serr.StatusCode < http.StatusInternalServerError
+}
+
+// refusal turns a refusal the sender can act on back into the error it was
+// refused with.
+func refusal(err error) error {
+	var serr *client.StatusError
+	if errors.As(err, &serr) && serr.StatusCode == http.StatusConflict {
+		return fmt.Errorf("%w: %v", ErrConflict, err)
+	}
+	return err
+}
+
+// changedKeys returns the keys the change msg is to.
+func changedKeys(msg proto.Message) []string {
+	if tx, ok := msg.(*pb.SharedDataTransaction); ok {
+		keys := make([]string, 0, len(tx.Ops))
+		for _, op := range tx.Ops {
+			keys = append(keys, op.Key)
+		}
+		return keys
+	}
+	m := msg.ProtoReflect()
+	field := m.Descriptor().Fields().ByName("key")
+	if field == nil || field.Kind() != protoreflect.StringKind || m.Get(field).String() == "" {
+		return nil
+	}
+	return []string{m.Get(field).String()}
+}
+
+// withSeq returns a copy of msg numbered seq.
+func withSeq(msg proto.Message, seq uint64) proto.Message {
+	msg = proto.Clone(msg)
+	m := msg.ProtoReflect()
+	m.Set(m.Descriptor().Fields().ByName("seq"), protoreflect.ValueOfUint64(seq))
+	return msg
+}
+
+// inbox puts the changes received from one member back in order.
+type inbox struct {
+	lock    sync.Mutex
+	log     common.CallCtx
+	next    uint64
+	pending map[uint64]func() error
+}
+
+func newInbox(log common.CallCtx) *inbox {
+	return &inbox{log: log, next: 1, pending: make(map[uint64]func() error)}
+}
+
+// deliver applies op, the seq'th change from the member, once every change
+// before it has been applied.  Changes that arrive early are held until the
+// gap is filled and ones that were already applied are ignored.
+func (i *inbox) deliver(seq uint64, op func() error) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	switch {
+	case seq < i.next:
+		return nil // A retry of something we already have
+	case seq > i.next:
+		i.log.Warn().Msgf("Holding change %d until %d arrives", seq, i.next)
+		i.pending[seq] = op
+		return nil
+	}
+
+	err := op()
+	i.next++
+
+	for {
+		op, ok := i.pending[i.next]
+		if !ok {
+			break
+		}
+		delete(i.pending, i.next)
+		if err := op(); err != nil {
+			i.log.Warn().Err(err).Msgf("Rejected held change %d", i.next)
+		}
+		i.next++
+	}
+
+	return err
+}
+
+// delivery is a change queued for some members, send sends it to them.
+type delivery struct {
+	outboxes  map[string]*outbox
+	envelopes map[string]*envelope
+}
+
+func (d *delivery) add(as string, o *outbox, e *envelope) {
+	if d.outboxes == nil {
+		d.outboxes = make(map[string]*outbox)
+		d.envelopes = make(map[string]*envelope)
+	}
+	d.outboxes[as] = o
+	d.envelopes[as] = e
+}
+
+// take removes the change for the member playing as from d, and returns it
+// to be sent on its own.
+func (d *delivery) take(as string) *delivery {
+	taken := &delivery{}
+	if o, ok := d.outboxes[as]; ok {
+		taken.add(as, o, d.envelopes[as])
+		delete(d.outboxes, as)
+		delete(d.envelopes, as)
+	}
+	return taken
+}
+
+// send returns a *DeliveryError if any of the members could not be reached.
+// The members are sent to at once, so one that is gone doesn't hold up the
+// rest.
+func (d *delivery) send() error {
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	var failed map[string]error
+	for as, o := range d.outboxes {
+		wg.Add(1)
+		go func(as string, o *outbox) {
+			defer wg.Done()
+			if err := o.send(d.envelopes[as]); err != nil {
+				lock.Lock()
+				defer lock.Unlock()
+				if failed == nil {
+					failed = make(map[string]error)
+				}
+				failed[as] = err
+			}
+		}(as, o)
+	}
+	wg.Wait()
+	if failed != nil {
+		return &DeliveryError{Failed: failed}
+	}
+	return nil
+}
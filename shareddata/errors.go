@@ -0,0 +1,54 @@
+package shareddata
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ErrNotOwner = errors.New("not the owner")
+var ErrNotMember = errors.New("not a member")
+var ErrUnknownKey = errors.New("unknown key")
+var ErrUnknownSharedData = errors.New("unknown shared data")
+var ErrWrongType = errors.New("wrong type")
+var ErrOutOfRange = errors.New("index out of range")
+var ErrConflict = errors.New("changed since it was read")
+var ErrUnknownOp = errors.New("unknown operation")
+var ErrUnknownInvite = errors.New("unknown invitation")
+var ErrInviteExpired = errors.New("invitation expired")
+var ErrInvalidToken = errors.New("invalid join token")
+var ErrInvalid = errors.New("not allowed by the schema")
+var ErrIncompatibleSchema = errors.New("incompatible schema")
+var ErrQueued = errors.New("queued behind changes that haven't been delivered yet")
+
+// DeliveryError is returned by a SharedData mutator when the change was made
+// locally but could not be sent to some of the other members.  Failed is
+// keyed by the role of each member that didn't get it, those changes are
+// resent in the background until they arrive or the member leaves.
+type DeliveryError struct {
+	Failed map[string]error
+}
+
+func (e *DeliveryError) Error() string {
+	roles := make([]string, 0, len(e.Failed))
+	for as := range e.Failed {
+		roles = append(roles, as)
+	}
+	sort.Strings(roles)
+
+	msgs := make([]string, len(roles))
+	for i, as := range roles {
+		msgs[i] = fmt.Sprintf("%v: %v", as, e.Failed[as])
+	}
+	return "could not deliver to " + strings.Join(msgs, ", ")
+}
+
+// Unwrap lets errors.Is and errors.As look at why each member failed.
+func (e *DeliveryError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
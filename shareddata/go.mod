@@ -3,7 +3,9 @@ module github.com/hoyle1974/grapevine/shareddata
 go 1.20
 
 require (
+	github.com/fxamacker/cbor/v2 v2.5.0
 	github.com/golang/protobuf v1.5.2
+	github.com/google/uuid v1.3.0
 	github.com/hoyle1974/grapevine/client v0.0.0-20230621055508-d62babcaa3f8
 	github.com/hoyle1974/grapevine/common v0.0.0-20230621055508-d62babcaa3f8
 	github.com/hoyle1974/grapevine/proto v0.0.0-20230621053926-fd2241b6cb16
@@ -13,7 +15,6 @@ require (
 	github.com/go-task/slim-sprig v0.0.0-20210107165309-348f09dbbbc0 // indirect
 	github.com/golang/mock v1.6.0 // indirect
 	github.com/google/pprof v0.0.0-20210407192527-94a9f03dee38 // indirect
-	github.com/google/uuid v1.3.0 // indirect
 	github.com/mattn/go-colorable v0.1.12 // indirect
 	github.com/mattn/go-isatty v0.0.14 // indirect
 	github.com/onsi/ginkgo/v2 v2.2.0 // indirect
@@ -23,6 +24,7 @@ require (
 	github.com/quic-go/quic-go v0.34.0 // indirect
 	github.com/rs/zerolog v1.29.1 // indirect
 	github.com/stretchr/testify v1.8.2 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
 	golang.org/x/crypto v0.8.0 // indirect
 	golang.org/x/exp v0.0.0-20221205204356-47842c84f3db // indirect
 	golang.org/x/mod v0.8.0 // indirect
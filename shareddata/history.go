@@ -0,0 +1,351 @@
+package shareddata
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hoyle1974/grapevine/common"
+	pb "github.com/hoyle1974/grapevine/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// historyLimit is how many changes a SharedData remembers, older ones are
+// forgotten as new ones are made.
+const historyLimit = 1000
+
+// OpId names a change in the history.  It is made from the key and the
+// version the change gave it, so every member that saw the change calls it
+// the same.
+type OpId string
+
+func opIdOf(key string, version uint64) OpId {
+	return OpId(fmt.Sprintf("%v@%d", key, version))
+}
+
+// HistoryEntry is a change kept in the history of a SharedData, OldValue
+// and NewValue are the key before and after as described for DataChange.
+// Time is when the change was made here.
+type HistoryEntry struct {
+	DataChange
+	Id   OpId
+	Time time.Time
+}
+
+// history keeps the latest changes made to a SharedData, every member keeps
+// its own as it applies the changes and new members are sent a copy.
+type history struct {
+	lock    sync.Mutex
+	entries []HistoryEntry
+	quiet   bool
+}
+
+func (h *history) record(change DataChange) {
+	h.lock.Lock()
+	quiet := h.quiet
+	h.lock.Unlock()
+	if quiet {
+		return
+	}
+	h.add(HistoryEntry{
+		DataChange: DataChange{
+			Key:        change.Key,
+			Path:       change.Path,
+			MapKey:     change.MapKey,
+			Index:      change.Index,
+			OldValue:   copyValue(change.OldValue),
+			NewValue:   copyValue(change.NewValue),
+			Originator: change.Originator,
+			Kind:       change.Kind,
+			Version:    change.Version,
+			Visibility: change.Visibility,
+		},
+		Id:   opIdOf(change.Key, change.Version),
+		Time: time.Now(),
+	})
+}
+
+func (h *history) add(entry HistoryEntry) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > historyLimit {
+		h.entries = append([]HistoryEntry(nil), h.entries[len(h.entries)-historyLimit:]...)
+	}
+}
+
+// last returns the most recent change.
+func (h *history) last() (HistoryEntry, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if len(h.entries) == 0 {
+		return HistoryEntry{}, false
+	}
+	return h.entries[len(h.entries)-1], true
+}
+
+// since returns the changes to key, or every key if key is "", made after
+// since, oldest first.
+func (h *history) since(key string, since time.Time) []HistoryEntry {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	var entries []HistoryEntry
+	for _, e := range h.entries {
+		if (key == "" || e.Key == key) && e.Time.After(since) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// find returns the change id.  A key that was deleted and created again
+// reuses versions, so the latest change with id is the one meant.
+func (h *history) find(id OpId) (HistoryEntry, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		if h.entries[i].Id == id {
+			return h.entries[i], nil
+		}
+	}
+	return HistoryEntry{}, fmt.Errorf("%w: %v", ErrUnknownOp, id)
+}
+
+// loading stops the changes f makes being recorded, they load state sent by
+// another member whose history comes with it.
+func (h *history) loading(f func()) {
+	h.lock.Lock()
+	h.quiet = true
+	h.lock.Unlock()
+
+	f()
+
+	h.lock.Lock()
+	h.quiet = false
+	h.lock.Unlock()
+}
+
+func (h *history) replace(entries []HistoryEntry) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.entries = entries
+}
+
+// merge adds the entries we don't have yet, in the order they were made.
+func (h *history) merge(entries []HistoryEntry) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	known := make(map[OpId]bool, len(h.entries))
+	for _, e := range h.entries {
+		known[e.Id] = true
+	}
+	for _, e := range entries {
+		if !known[e.Id] {
+			h.entries = append(h.entries, e)
+		}
+	}
+	sort.SliceStable(h.entries, func(i, j int) bool { return h.entries[i].Time.Before(h.entries[j].Time) })
+	if len(h.entries) > historyLimit {
+		h.entries = append([]HistoryEntry(nil), h.entries[len(h.entries)-historyLimit:]...)
+	}
+}
+
+// copyValue copies the arrays and maps in value, all the way down, so the
+// copy is unaffected by changes made to value after.
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = copyValue(e)
+		}
+		return a
+	case map[string]interface{}:
+		return copyMap(v)
+	}
+	return value
+}
+
+func copyMap(v map[string]interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	m := make(map[string]interface{}, len(v))
+	for k, e := range v {
+		m[k] = copyValue(e)
+	}
+	return m
+}
+
+// History returns the changes to key made after since, or to every key if
+// key is "".
+func (s *sharedData) History(key string, since time.Time) []HistoryEntry {
+	return s.history.since(key, since)
+}
+
+// Undo makes the change that reverses the change id, as long as we own its
+// key.  Changes to arrays are only undone if the elements they added are
+// still where they were put.
+func (s *sharedData) Undo(id OpId) error {
+	entry, err := s.history.find(id)
+	if err != nil {
+		return err
+	}
+	return undo(s, entry)
+}
+
+// undo reverses entry by making the opposite change to sd.
+func undo(sd SharedData, entry HistoryEntry) error {
+	key := entry.Key
+	if entry.Path != "" {
+		return undoPath(sd, entry)
+	}
+	if entry.Kind == DeleteChange {
+		return fmt.Errorf("%w: %v was deleted, it can't be undone", ErrWrongType, key)
+	}
+	if owner := sd.GetOwner(key); !sd.IsMe(owner) {
+		return fmt.Errorf("%w: %v can't undo changes to %v, it is owned by %v", ErrNotOwner, sd.GetMe(), key, owner)
+	}
+
+	switch entry.Kind {
+	case CreateChange:
+		if entry.OldValue == nil {
+			return sd.Delete(key)
+		}
+		return sd.Set(key, entry.OldValue)
+	case SetChange:
+		return sd.Set(key, entry.OldValue)
+	case SetMapChange:
+		if entry.OldValue == nil {
+			return sd.DeleteMapKey(key, entry.MapKey)
+		}
+		return sd.SetMap(key, entry.MapKey, entry.OldValue)
+	case DeleteMapChange:
+		return sd.SetMap(key, entry.MapKey, entry.OldValue)
+	case AppendChange:
+		array, _ := sd.Get(key).([]interface{})
+		for i := len(array) - 1; i >= 0; i-- {
+			if reflect.DeepEqual(array[i], entry.NewValue) {
+				return sd.RemoveAt(key, i)
+			}
+		}
+		return fmt.Errorf("%w: %v no longer has %v", ErrConflict, key, entry.NewValue)
+	case SpliceChange:
+		removed, _ := entry.OldValue.([]interface{})
+		inserted, _ := entry.NewValue.([]interface{})
+		array, _ := sd.Get(key).([]interface{})
+		end := entry.Index + len(inserted)
+		if end > len(array) || (len(inserted) > 0 && !reflect.DeepEqual(array[entry.Index:end], inserted)) {
+			return fmt.Errorf("%w: %v has changed at %d", ErrConflict, key, entry.Index)
+		}
+		return sd.Splice(key, entry.Index, len(inserted), removed...)
+	case OwnerChange:
+		return sd.ChangeDataOwner(key, fmt.Sprint(entry.OldValue))
+	case VisibilityChange:
+		return sd.SetVisibility(key, fmt.Sprint(entry.OldValue))
+	case SuccessorChange:
+		return sd.SetSuccessor(key, fmt.Sprint(entry.OldValue))
+	case MergeChange:
+		// Only counters can be taken back, by counting the other way
+		before, ok1 := entry.OldValue.(int64)
+		after, ok2 := entry.NewValue.(int64)
+		if ok1 && ok2 {
+			return sd.Increment(key, before-after)
+		}
+	}
+	return fmt.Errorf("%w: %v changes to %v can't be undone", ErrWrongType, entry.Kind, key)
+}
+
+// undoPath is undo for changes made to a part of a key.
+func undoPath(sd SharedData, entry HistoryEntry) error {
+	path := joinPath([]string{entry.Key}) + "/" + entry.Path
+	if owner := sd.GetPathOwner(path); !sd.IsMe(owner) {
+		return fmt.Errorf("%w: %v can't undo changes to %v, it is owned by %v", ErrNotOwner, sd.GetMe(), path, owner)
+	}
+
+	switch entry.Kind {
+	case SetChange:
+		if entry.OldValue == nil {
+			return sd.DeletePath(path)
+		}
+		return sd.SetPath(path, entry.OldValue)
+	case AppendChange:
+		array, _ := sd.GetPath(path).([]interface{})
+		for i := len(array) - 1; i >= 0; i-- {
+			if reflect.DeepEqual(array[i], entry.NewValue) {
+				return sd.DeletePath(path + "/" + strconv.Itoa(i))
+			}
+		}
+		return fmt.Errorf("%w: %v no longer has %v", ErrConflict, path, entry.NewValue)
+	case OwnerChange:
+		return sd.SetPathOwner(path, fmt.Sprint(entry.OldValue))
+	case VisibilityChange:
+		return sd.SetPathVisibility(path, fmt.Sprint(entry.OldValue))
+	}
+	return fmt.Errorf("%w: %v changes to %v can't be undone", ErrWrongType, entry.Kind, path)
+}
+
+// encodeHistory encodes entry to be sent, with its values only if visible.
+func encodeHistory(codec Codec, entry HistoryEntry, visible bool) (*pb.SharedDataHistoryEntry, error) {
+	e := &pb.SharedDataHistoryEntry{
+		Key:        entry.Key,
+		Path:       entry.Path,
+		MapKey:     entry.MapKey,
+		Index:      int64(entry.Index),
+		Kind:       int32(entry.Kind),
+		Originator: entry.Originator.ToPB(),
+		Time:       timestamppb.New(entry.Time),
+		Version:    entry.Version,
+		Visibility: entry.Visibility,
+	}
+	if visible {
+		before, err := encode(codec, entry.OldValue)
+		if err != nil {
+			return nil, err
+		}
+		after, err := encode(codec, entry.NewValue)
+		if err != nil {
+			return nil, err
+		}
+		e.Before, e.After, e.Codec = before, after, codec.Id()
+	}
+	return e, nil
+}
+
+func decodeHistory(e *pb.SharedDataHistoryEntry) (HistoryEntry, error) {
+	before, err := decodeCRDT(e.Codec, e.Before)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	after, err := decodeCRDT(e.Codec, e.After)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	return HistoryEntry{
+		DataChange: DataChange{
+			Key:        e.Key,
+			Path:       e.Path,
+			MapKey:     e.MapKey,
+			Index:      int(e.Index),
+			OldValue:   before,
+			NewValue:   after,
+			Originator: common.NewContactFromPB(e.Originator),
+			Kind:       ChangeKind(e.Kind),
+			Version:    e.Version,
+			Visibility: e.Visibility,
+		},
+		Id:   opIdOf(e.Key, e.Version),
+		Time: e.Time.AsTime(),
+	}, nil
+}
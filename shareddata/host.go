@@ -0,0 +1,97 @@
+package shareddata
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hoyle1974/grapevine/common"
+	pb "github.com/hoyle1974/grapevine/proto"
+)
+
+// The member that created a shared data hosts it, it is who new members
+// join through and the only one that may define groups.  When the host
+// leaves, or its lease runs out, the live member whose role sorts first
+// takes over and tells the others, who all reach the same choice.
+
+func (s *sharedData) GetHost() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.host
+}
+
+// setHost makes originator the host in place of previous, the creator stays
+// who created it.
+func (s *sharedData) setHost(originator member, previous string) error {
+	if s.host != previous {
+		return fmt.Errorf("%w: %v is hosted by %v, not %v", ErrConflict, s.id, s.host, previous)
+	}
+	s.host = originator.as
+	s.hostAt = originator.contact
+	return nil
+}
+
+func (p *sharedDataProxy) GetHost() string {
+	return p.origin.GetHost()
+}
+
+// hostChanged has the callback told that the member playing as, reachable
+// at contact, now hosts the shared data.
+func (p *sharedDataProxy) hostChanged(as string, contact common.Contact) {
+	p.snapshot()
+	go p.sdm.cb.OnHostChanged(p, as, contact)
+}
+
+// hostTaken applies originator taking over hosting from previous, which
+// must have gone or whose lease must have run out.  It is called with the
+// lock held.
+func (p *sharedDataProxy) hostTaken(originator member, previous string) error {
+	if err := p.checkTakeover(originator, data{owner: previous}); err != nil {
+		return err
+	}
+	if err := originOf(p).setHost(originator, previous); err != nil {
+		return err
+	}
+	p.hostChanged(originator.as, originator.contact)
+	return nil
+}
+
+// checkHost takes over hosting the shared data if the host is gone and we
+// are the one to replace it.
+func (p *sharedDataProxy) checkHost() {
+	p.lock.Lock()
+	host := originOf(p).host
+	_, present := p.invities[host]
+	now := time.Now()
+	ours := host != "" && (!present || p.expired(host, now)) && p.successorOf(data{owner: host}, now) == p.GetMe()
+	p.lock.Unlock()
+
+	if !ours {
+		return
+	}
+	go func() {
+		var derr *DeliveryError
+		if err := p.takeHost(host); err != nil && !errors.As(err, &derr) {
+			p.sdm.ctx.Warn().Err(err).Msgf("Could not take over hosting from %v", host)
+		}
+	}()
+}
+
+// takeHost makes us the host in place of previous.
+func (p *sharedDataProxy) takeHost(previous string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.setHost(origin.self(), previous); err != nil {
+			return nil, err
+		}
+		p.hostChanged(p.GetMe(), p.sdm.GetMe())
+
+		req := pb.SharedDataHost{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Previous:     previous,
+		}
+		resp := pb.SharedDataHostResponse{}
+		return p.broadcast("/shareddata/host", "", &req, nil, &resp), nil
+	})
+}
@@ -0,0 +1,303 @@
+package shareddata
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hoyle1974/grapevine/client"
+	"github.com/hoyle1974/grapevine/common"
+	pb "github.com/hoyle1974/grapevine/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Invite asks the recipient to answer straight away, inside the request.
+// SendInvite instead leaves the invitation with them to accept or decline
+// whenever they like, until it expires or is revoked.
+
+// InviteId names an invitation sent with SendInvite.
+type InviteId string
+
+// Invitation invites To to join a shared data as the role As.  From is who
+// sent it and who it is answered to.
+type Invitation struct {
+	Id           InviteId
+	SharedDataId SharedDataId
+	As           string
+	From         common.Contact
+	To           common.Contact
+	Message      string
+	Metadata     map[string]string
+	Expires      time.Time
+	host         common.Contact
+}
+
+// InviteOptions is what is sent along with an invitation.
+type InviteOptions struct {
+	Message  string
+	Metadata map[string]string
+	// Expiry is how long the invitation may be answered for, a day if it is 0
+	Expiry time.Duration
+}
+
+const defaultInviteExpiry = 24 * time.Hour
+
+func (i Invitation) expired(now time.Time) bool {
+	return now.After(i.Expires)
+}
+
+// SendInvite invites recipient to join s as the role as.  They are told with
+// OnInviteReceived and we are told of their answer with OnInviteAccepted or
+// OnInviteDeclined.
+func (sdm *sharedDataManager) SendInvite(s SharedData, recipient common.Contact, as string, options InviteOptions) (Invitation, error) {
+	sdm.lock.Lock()
+	defer sdm.lock.Unlock()
+
+	proxy, ok := sdm.data[s.GetId()]
+	if !ok {
+		return Invitation{}, fmt.Errorf("%w: %v", ErrUnknownSharedData, s.GetId())
+	}
+	expiry := options.Expiry
+	if expiry == 0 {
+		expiry = defaultInviteExpiry
+	}
+	invite := Invitation{
+		Id:           InviteId(uuid.NewString()),
+		SharedDataId: s.GetId(),
+		As:           as,
+		From:         sdm.GetMe(),
+		To:           recipient,
+		Message:      options.Message,
+		Metadata:     options.Metadata,
+		Expires:      time.Now().Add(expiry),
+		host:         originOf(proxy).hostContact(),
+	}
+
+	req := pb.SharedDataOffer{
+		InviteId:     string(invite.Id),
+		SharedDataId: string(invite.SharedDataId),
+		Originator:   invite.From.ToPB(),
+		Creator:      invite.host.ToPB(),
+		As:           as,
+		Message:      invite.Message,
+		Metadata:     invite.Metadata,
+		Expires:      timestamppb.New(invite.Expires),
+	}
+	if schema := s.GetSchema(); schema != nil {
+		req.Schema, req.SchemaVersion = schema.Name, int32(schema.Version)
+	}
+	resp := pb.SharedDataOfferResponse{}
+	if err := sdm.clientCache.POST(recipient.Address, "/shareddata/offer", &req, &resp); err != nil {
+		return Invitation{}, answerError(invite.Id, err)
+	}
+	sdm.outgoing[invite.Id] = invite
+	return invite, nil
+}
+
+// RevokeInvite withdraws an invitation we sent that hasn't been answered.
+// Should the recipient not hear of it they are refused if they accept.
+func (sdm *sharedDataManager) RevokeInvite(id InviteId) error {
+	log := sdm.ctx.NewCtx("RevokeInvite")
+
+	sdm.lock.Lock()
+	defer sdm.lock.Unlock()
+
+	invite, ok := sdm.outgoing[id]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownInvite, id)
+	}
+	delete(sdm.outgoing, id)
+
+	req := pb.SharedDataRevoke{InviteId: string(id), Originator: sdm.GetMe().ToPB()}
+	resp := pb.SharedDataRevokeResponse{}
+	if err := sdm.clientCache.POST(invite.To.Address, "/shareddata/revoke", &req, &resp); err != nil {
+		log.Warn().Err(err).Msgf("Could not tell %v the invite was revoked", invite.To)
+	}
+	return nil
+}
+
+// AcceptInvite joins the shared data we were invited to with id, which is
+// also handed to OnSharedDataAvailable.
+func (sdm *sharedDataManager) AcceptInvite(id InviteId) (SharedData, error) {
+	sdm.lock.Lock()
+	defer sdm.lock.Unlock()
+
+	invite, err := sdm.findIncoming(id)
+	if err != nil {
+		return nil, err
+	}
+
+	req := pb.SharedDataAnswer{InviteId: string(id), Originator: sdm.GetMe().ToPB(), Accepted: true}
+	resp := pb.SharedDataAnswerResponse{}
+	if err := sdm.clientCache.POST(invite.From.Address, "/shareddata/answer", &req, &resp); err != nil {
+		// It can be answered again unless the sender turned it down
+		if isRefused(err) {
+			delete(sdm.incoming, id)
+		}
+		return nil, answerError(id, err)
+	}
+	delete(sdm.incoming, id)
+
+	return sdm.enter(invite.host, invite.SharedDataId, invite.As, invite.From, resp.State, resp.Next)
+}
+
+// DeclineInvite turns down the invitation id, the sender is given reason.
+func (sdm *sharedDataManager) DeclineInvite(id InviteId, reason string) error {
+	sdm.lock.Lock()
+	defer sdm.lock.Unlock()
+
+	invite, err := sdm.findIncoming(id)
+	if err != nil {
+		return err
+	}
+
+	req := pb.SharedDataAnswer{InviteId: string(id), Originator: sdm.GetMe().ToPB(), Reason: reason}
+	resp := pb.SharedDataAnswerResponse{}
+	if err := sdm.clientCache.POST(invite.From.Address, "/shareddata/answer", &req, &resp); err != nil {
+		if isRefused(err) {
+			delete(sdm.incoming, id)
+		}
+		return answerError(id, err)
+	}
+	delete(sdm.incoming, id)
+	return nil
+}
+
+// IncomingInvites returns the invitations we haven't answered yet, soonest
+// to expire first.
+func (sdm *sharedDataManager) IncomingInvites() []Invitation {
+	sdm.lock.Lock()
+	defer sdm.lock.Unlock()
+	return pending(sdm.incoming)
+}
+
+// OutgoingInvites returns the invitations we sent that haven't been
+// answered yet, soonest to expire first.
+func (sdm *sharedDataManager) OutgoingInvites() []Invitation {
+	sdm.lock.Lock()
+	defer sdm.lock.Unlock()
+	return pending(sdm.outgoing)
+}
+
+// pending drops the expired invites and returns the rest.  It is called
+// with the lock held.
+func pending(invites map[InviteId]Invitation) []Invitation {
+	now := time.Now()
+	list := make([]Invitation, 0, len(invites))
+	for id, invite := range invites {
+		if invite.expired(now) {
+			delete(invites, id)
+			continue
+		}
+		list = append(list, invite)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Expires.Before(list[j].Expires) })
+	return list
+}
+
+// findIncoming returns the invitation id we are answering.  It is kept
+// until the sender has the answer, unless it has expired.  It is called with
+// the lock held.
+func (sdm *sharedDataManager) findIncoming(id InviteId) (Invitation, error) {
+	invite, ok := sdm.incoming[id]
+	if !ok {
+		return Invitation{}, fmt.Errorf("%w: %v", ErrUnknownInvite, id)
+	}
+	if invite.expired(time.Now()) {
+		delete(sdm.incoming, id)
+		return Invitation{}, fmt.Errorf("%w: %v", ErrInviteExpired, id)
+	}
+	return invite, nil
+}
+
+// takeOutgoing removes the invitation id that from is answering.  It is
+// called with the lock held.
+func (sdm *sharedDataManager) takeOutgoing(id InviteId, from common.Contact) (Invitation, error) {
+	invite, ok := sdm.outgoing[id]
+	if !ok {
+		return Invitation{}, fmt.Errorf("%w: %v", ErrUnknownInvite, id)
+	}
+	if invite.To.AccountId != from.AccountId {
+		return Invitation{}, fmt.Errorf("%w: %v wasn't sent to %v", ErrNotMember, id, from)
+	}
+	delete(sdm.outgoing, id)
+	if invite.expired(time.Now()) {
+		return Invitation{}, fmt.Errorf("%w: %v", ErrInviteExpired, id)
+	}
+	return invite, nil
+}
+
+// admit makes contact a member of the shared data id as the role as, and
+// returns the first part of the state to send them.  The other members are
+// not told, the caller announces them once the lock is released.  It is
+// called with the lock held.
+func (sdm *sharedDataManager) admit(id SharedDataId, contact common.Contact, as string) (SharedDataProxy, *pb.SharedDataSendState, string, error) {
+	proxy, ok := sdm.data[id]
+	if !ok {
+		return nil, nil, "", fmt.Errorf("%w: %v", ErrUnknownSharedData, id)
+	}
+	proxy.AddInvitee(contact, as)
+
+	var state *pb.SharedDataSendState
+	var next string
+	var err error
+	proxy.withLock(func() {
+		state, next, err = proxy.getStateSince(as, nil, "", stateChunkSize)
+	})
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return proxy, state, next, nil
+}
+
+// enter sets up the shared data id, hosted by host, that we were admitted
+// to as the role as, from the state sent by from.  It is called with the
+// lock held.
+func (sdm *sharedDataManager) enter(host common.Contact, id SharedDataId, as string, from common.Contact, state *pb.SharedDataSendState, next string) (SharedData, error) {
+	sd := NewSharedData(host, id)
+	sd.SetMe(as)
+	proxy := NewSharedDataProxy(sd, sdm)
+	if err := sdm.fetchState(proxy, from, state, next); err != nil {
+		proxy.stop()
+		return nil, err
+	}
+	proxy.AddInvitee(sdm.GetMe(), as)
+	sdm.data[id] = proxy
+
+	sdm.cb.OnSharedDataAvailable(proxy)
+	return proxy, nil
+}
+
+func invitationFromPB(req *pb.SharedDataOffer, to common.Contact) Invitation {
+	return Invitation{
+		Id:           InviteId(req.InviteId),
+		SharedDataId: SharedDataId(req.SharedDataId),
+		As:           req.As,
+		From:         common.NewContactFromPB(req.Originator),
+		To:           to,
+		Message:      req.Message,
+		Metadata:     req.Metadata,
+		Expires:      req.Expires.AsTime(),
+		host:         common.NewContactFromPB(req.Creator),
+	}
+}
+
+// answerError turns the refusal of the offer of id, or an answer to it,
+// back into why it was refused.
+func answerError(id InviteId, err error) error {
+	var serr *client.StatusError
+	if errors.As(err, &serr) {
+		switch serr.StatusCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: %v was revoked", ErrUnknownInvite, id)
+		case http.StatusGone:
+			return fmt.Errorf("%w: %v", ErrInviteExpired, id)
+		case http.StatusPreconditionFailed:
+			return fmt.Errorf("%w: %v", ErrIncompatibleSchema, id)
+		}
+	}
+	return err
+}
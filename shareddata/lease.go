@@ -0,0 +1,340 @@
+package shareddata
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hoyle1974/grapevine/common"
+	pb "github.com/hoyle1974/grapevine/proto"
+)
+
+// Keys owned by a single member are leased to it.  Every member sends the
+// others a heartbeat each heartbeatInterval, and any change it sends counts
+// as one too, which renews the leases on the keys it owns.  Once we haven't
+// heard from an owner for leaseDuration its keys go to their successor, or
+// if it isn't around either to the live member whose role sorts first.
+// Everyone works out the same member, which takes the key and tells the
+// others.  A shared data keeps the durations in force when it was set up.
+
+var heartbeatInterval = time.Second
+var leaseDuration = 5 * time.Second
+
+// SetSuccessor names the role that takes over key if the lease of its
+// owner runs out.
+func (s *sharedData) SetSuccessor(key string, successor string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.setSuccessor(s.self(), key, successor)
+}
+
+func (s *sharedData) setSuccessor(originator member, key string, successor string) error {
+	if err := s.checkOwner(originator, key); err != nil {
+		return err
+	}
+	d := s.data[key]
+	old := d.successor
+	d.successor = successor
+	s.data[key] = d
+	s.changed(DataChange{Key: key, OldValue: old, NewValue: successor, Originator: originator.contact, Kind: SuccessorChange})
+	return nil
+}
+
+func (s *sharedData) GetSuccessor(key string) string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.data[key].successor
+}
+
+// reassign hands key over from previous, whose lease ran out, to owner.
+func (s *sharedData) reassign(originator member, key string, previous string, owner string) error {
+	d, ok := s.data[key]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownKey, key)
+	}
+	if d.owner != previous {
+		return fmt.Errorf("%w: %v is owned by %v, not %v", ErrConflict, key, d.owner, previous)
+	}
+	d.owner = owner
+	s.data[key] = d
+	s.changed(DataChange{Key: key, OldValue: previous, NewValue: owner, Originator: originator.contact, Kind: OwnerChange})
+	return nil
+}
+
+// RequestOwnership asks the owner of key to hand it to us with
+// GrantOwnership, it is told with an OwnershipRequest DataChange.  Until the
+// shared data is served there is no one else to ask.
+func (s *sharedData) RequestOwnership(key string) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if _, ok := s.data[key]; !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownKey, key)
+	}
+	if s.members.includes(s.data[key].owner, s.me) {
+		return nil
+	}
+	return fmt.Errorf("%w: there is no one to ask for %v", ErrNotMember, key)
+}
+
+// GrantOwnership hands key, which we own, to the member playing to.
+func (s *sharedData) GrantOwnership(key string, to string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.grantOwnership(s.self(), key, to)
+}
+
+func (s *sharedData) grantOwnership(originator member, key string, to string) error {
+	if !s.members.roles[to] {
+		return fmt.Errorf("%w: %v", ErrNotMember, to)
+	}
+	return s.changeDataOwner(originator, key, to)
+}
+
+func (p *sharedDataProxy) SetSuccessor(key string, successor string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.setSuccessor(origin.self(), key, successor); err != nil {
+			return nil, err
+		}
+
+		req := pb.SharedDataSetSuccessor{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Successor:    successor,
+		}
+		resp := pb.SharedDataSetSuccessorResponse{}
+		return p.broadcast("/shareddata/setsuccessor", "", &req, nil, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) GetSuccessor(key string) string {
+	return p.origin.GetSuccessor(key)
+}
+
+func (p *sharedDataProxy) RequestOwnership(key string) error {
+	p.lock.Lock()
+	_, exists := originOf(p).data[key]
+	owner := originOf(p).data[key].owner
+	contact, ok := p.invities[owner]
+	p.lock.Unlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %v", ErrUnknownKey, key)
+	}
+	if p.IsMe(owner) {
+		return nil
+	}
+	if !ok {
+		return fmt.Errorf("%w: %v is owned by %v, which isn't a member", ErrNotMember, key, owner)
+	}
+
+	req := pb.SharedDataRequestOwnership{
+		SharedDataId: string(p.origin.GetId()),
+		Originator:   p.sdm.GetMe().ToPB(),
+		Key:          key,
+	}
+	resp := pb.SharedDataRequestOwnershipResponse{}
+	return p.sdm.clientCache.POST(contact.Address, "/shareddata/requestownership", &req, &resp)
+}
+
+func (p *sharedDataProxy) GrantOwnership(key string, to string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.grantOwnership(origin.self(), key, to); err != nil {
+			return nil, err
+		}
+
+		req := pb.SharedDataChangeOwner{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Owner:        to,
+		}
+		resp := pb.SharedDataChangeOwnerResponse{}
+		return p.broadcast("/shareddata/changeowner", "", &req, nil, &resp), nil
+	})
+}
+
+// ownershipRequested tells the listeners that the member playing as would
+// like to own key.
+func (p *sharedDataProxy) ownershipRequested(originator member, key string) error {
+	if !p.IsMe(p.GetOwner(key)) {
+		return fmt.Errorf("%w: %v isn't ours to give", ErrNotOwner, key)
+	}
+	originOf(p).listeners.tell(DataChange{Key: key, NewValue: originator.as, Originator: originator.contact, Kind: OwnershipRequest})
+	return nil
+}
+
+// renew renews the leases of the member playing as, it is called with the
+// lock held.
+func (p *sharedDataProxy) renew(as string) {
+	p.seen[as] = time.Now()
+}
+
+// expired returns true if the lease of the member playing as has run out.
+// It is called with the lock held.
+func (p *sharedDataProxy) expired(as string, now time.Time) bool {
+	if as == p.GetMe() {
+		return false
+	}
+	seen, ok := p.seen[as]
+	return ok && now.Sub(seen) > p.lease
+}
+
+// lapsed is expired for the lease of a member another says has run out.
+// Their clock, and when they last heard from it, differ a little from ours
+// so it only has to have been quiet for half the lease here.  It is called
+// with the lock held.
+func (p *sharedDataProxy) lapsed(as string, now time.Time) bool {
+	if as == p.GetMe() {
+		return false
+	}
+	seen, ok := p.seen[as]
+	return ok && now.Sub(seen) > p.lease/2
+}
+
+// checkTakeover returns an error unless the lease of the owner of d has run
+// out, or it has gone, and originator is who we would choose to take over
+// from it.  It is called with the lock held.
+func (p *sharedDataProxy) checkTakeover(originator member, d data) error {
+	now := time.Now()
+	if _, present := p.invities[d.owner]; present && !p.lapsed(d.owner, now) {
+		return fmt.Errorf("%w: the lease of %v hasn't run out", ErrConflict, d.owner)
+	}
+	if successor := p.successorOf(d, now); successor != originator.as {
+		return fmt.Errorf("%w: %v takes over from %v, not %v", ErrNotOwner, successor, d.owner, originator.as)
+	}
+	return nil
+}
+
+// reassigned applies a reassign from originator, who must be taking key for
+// itself from previous, whose lease ran out.  It is called with the lock
+// held.
+func (p *sharedDataProxy) reassigned(originator member, key string, previous string, owner string) error {
+	if originator.as != owner {
+		return fmt.Errorf("%w: %v can't hand %v to %v", ErrNotOwner, originator.as, key, owner)
+	}
+	origin := originOf(p)
+	if d, ok := origin.data[key]; ok && d.owner == previous {
+		if err := p.checkTakeover(originator, d); err != nil {
+			return err
+		}
+	}
+	return origin.reassign(originator, key, previous, owner)
+}
+
+// successorOf picks who takes over d from its owner, whose lease has run
+// out, or "" if nobody can.  It is called with the lock held.
+func (p *sharedDataProxy) successorOf(d data, now time.Time) string {
+	if _, ok := p.invities[d.successor]; ok && d.successor != d.owner && !p.expired(d.successor, now) {
+		return d.successor
+	}
+
+	roles := make([]string, 0, len(p.invities))
+	for as := range p.invities {
+		roles = append(roles, as)
+	}
+	sort.Strings(roles)
+	for _, as := range roles {
+		if as != d.owner && !p.expired(as, now) {
+			return as
+		}
+	}
+	return ""
+}
+
+// heartbeat sends the other members heartbeats, and takes over the keys of
+// any whose lease has run out, until we leave.
+func (p *sharedDataProxy) heartbeat(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// A member still busy with our last heartbeat isn't sent another
+	sending := make(map[string]bool)
+	sent := make(chan string)
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case as := <-sent:
+			delete(sending, as)
+			continue
+		case <-ticker.C:
+		}
+
+		req := pb.SharedDataHeartbeat{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+		}
+		for as, invitee := range p.GetInvitees() {
+			if as == p.GetMe() || sending[as] {
+				continue
+			}
+			sending[as] = true
+			go func(as string, invitee common.Contact) {
+				// Those that can't be reached will find out when our lease runs out
+				p.sdm.clientCache.POST(invitee.Address, "/shareddata/heartbeat", &req, &pb.SharedDataHeartbeatResponse{})
+				select {
+				case sent <- as:
+				case <-p.done:
+				}
+			}(as, invitee)
+		}
+
+		p.checkLeases()
+		p.checkHost()
+	}
+}
+
+// checkLeases takes over the keys whose owner's lease has run out, if we
+// are the one to take them.
+func (p *sharedDataProxy) checkLeases() {
+	type lapsed struct {
+		key   string
+		owner string
+	}
+	var ours []lapsed
+
+	p.lock.Lock()
+	now := time.Now()
+	for key, d := range originOf(p).data {
+		if p.expired(d.owner, now) && p.successorOf(d, now) == p.GetMe() {
+			ours = append(ours, lapsed{key, d.owner})
+		}
+	}
+	p.lock.Unlock()
+
+	for _, l := range ours {
+		// Telling the one whose lease ran out takes a while, our heartbeats
+		// can't wait for it
+		go func(l lapsed) {
+			var derr *DeliveryError
+			if err := p.takeOver(l.key, l.owner); err != nil && !errors.As(err, &derr) {
+				p.sdm.ctx.Warn().Err(err).Msgf("Could not take %v over from %v", l.key, l.owner)
+			}
+		}(l)
+	}
+}
+
+// takeOver makes us the owner of key, whose owner's lease ran out.
+func (p *sharedDataProxy) takeOver(key string, previous string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.reassign(origin.self(), key, previous, p.GetMe()); err != nil {
+			return nil, err
+		}
+
+		req := pb.SharedDataReassign{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Previous:     previous,
+			Owner:        p.GetMe(),
+		}
+		resp := pb.SharedDataReassignResponse{}
+		return p.broadcast("/shareddata/reassign", "", &req, nil, &resp), nil
+	})
+}
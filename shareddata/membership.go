@@ -0,0 +1,93 @@
+package shareddata
+
+import (
+	"fmt"
+
+	"github.com/hoyle1974/grapevine/common"
+)
+
+// DefaultGroup is the owner name for keys that every member may write.
+const DefaultGroup = "default"
+
+// member is someone taking part in a SharedData and the role they play in it.
+type member struct {
+	contact common.Contact
+	as      string
+}
+
+// membership tracks the roles taking part in a SharedData and the named
+// groups of roles, so owner and visibility names can be resolved to the
+// roles allowed to write or see a key.
+type membership struct {
+	roles  map[string]bool
+	groups map[string][]string
+}
+
+func (m *membership) addRole(role string) {
+	if m.roles == nil {
+		m.roles = make(map[string]bool)
+	}
+	m.roles[role] = true
+}
+
+func (m *membership) removeRole(role string) {
+	delete(m.roles, role)
+}
+
+func (m *membership) defineGroup(group string, roles []string) {
+	if m.groups == nil {
+		m.groups = make(map[string][]string)
+	}
+	m.groups[group] = append([]string(nil), roles...)
+}
+
+func (m *membership) getGroup(group string) []string {
+	return append([]string(nil), m.groups[group]...)
+}
+
+// includes returns true if role may act as owner, which is either a role or
+// a group name.
+func (m *membership) includes(owner string, role string) bool {
+	if owner == role {
+		return true
+	}
+	if owner == DefaultGroup {
+		return m.roles[role]
+	}
+	for _, r := range m.groups[owner] {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// canSee returns true if role may see the values of keys with visibility.
+func (m *membership) canSee(visibility string, role string) bool {
+	return visibility == "" || m.includes(visibility, role)
+}
+
+// checkOwner returns an error unless originator may write key.
+func (s *sharedData) checkOwner(originator member, key string) error {
+	d, ok := s.data[key]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownKey, key)
+	}
+	if !s.members.includes(d.owner, originator.as) {
+		return fmt.Errorf("%w: %v can't write %v, it is owned by %v", ErrNotOwner, originator.as, key, d.owner)
+	}
+	return nil
+}
+
+// checkCreator returns an error unless originator hosts the shared data,
+// which is its creator until another member takes over, only the host may
+// define groups.  Until it is served the creator is the only one there is.
+func (s *sharedData) checkCreator(originator member) error {
+	if s.host == "" && originator.contact.AccountId == s.creator.AccountId {
+		return nil
+	}
+	if s.host == "" || originator.as != s.host {
+		return fmt.Errorf("%w: only the host can define groups", ErrNotOwner)
+	}
+	return nil
+}
@@ -0,0 +1,663 @@
+package shareddata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pb "github.com/hoyle1974/grapevine/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Paths name a part of the value of a key, like a JSON pointer:
+// "players/2/hand/3" is element 3 of the hand of element 2 of the key
+// players.  Maps are walked by key and arrays by index, a "/" in a step is
+// written "~1" and a "~" is written "~0".  A part can be given its own owner
+// and visibility, which everything below it inherits.  Members only see a
+// part if they can see the key and every part above it that has a
+// visibility of its own.
+
+var unescapeStep = strings.NewReplacer("~1", "/", "~0", "~")
+var escapeStep = strings.NewReplacer("~", "~0", "/", "~1")
+
+// splitPath returns the key path starts with and the steps below it.
+func splitPath(path string) (string, []string, error) {
+	steps := parseSteps(strings.TrimPrefix(path, "/"))
+	if len(steps) == 0 || steps[0] == "" {
+		return "", nil, fmt.Errorf("%w: %q has no key", ErrUnknownKey, path)
+	}
+	return steps[0], steps[1:], nil
+}
+
+func parseSteps(path string) []string {
+	if path == "" {
+		return nil
+	}
+	steps := strings.Split(path, "/")
+	for i, step := range steps {
+		steps[i] = unescapeStep.Replace(step)
+	}
+	return steps
+}
+
+func joinPath(steps []string) string {
+	escaped := make([]string, len(steps))
+	for i, step := range steps {
+		escaped[i] = escapeStep.Replace(step)
+	}
+	return strings.Join(escaped, "/")
+}
+
+// below returns the steps of path under the part at prefix, and false if
+// it isn't under it.
+func below(path string, prefix string) ([]string, bool) {
+	if prefix == "" {
+		return parseSteps(path), true
+	}
+	if !strings.HasPrefix(path, prefix+"/") {
+		return nil, false
+	}
+	return parseSteps(path[len(prefix)+1:]), true
+}
+
+// index returns the element of an array of n elements step names, n itself
+// is allowed, as is "-" for it, if end is true.
+func index(step string, n int, end bool) (int, error) {
+	if end && step == "-" {
+		return n, nil
+	}
+	i, err := strconv.Atoi(step)
+	if err != nil || i < 0 || i > n || (i == n && !end) {
+		return 0, fmt.Errorf("%w: %v of %d", ErrOutOfRange, step, n)
+	}
+	return i, nil
+}
+
+// getIn returns the part of value at steps.
+func getIn(value interface{}, steps []string) (interface{}, error) {
+	for _, step := range steps {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			e, ok := v[step]
+			if !ok {
+				return nil, fmt.Errorf("%w: %v", ErrUnknownKey, step)
+			}
+			value = e
+		case []interface{}:
+			i, err := index(step, len(v), false)
+			if err != nil {
+				return nil, err
+			}
+			value = v[i]
+		default:
+			return nil, fmt.Errorf("%w: a %T has no %v", ErrWrongType, value, step)
+		}
+	}
+	return value, nil
+}
+
+// updateIn returns a copy of value with the part at steps replaced by what
+// change returns for it, or removed if change returns false.  Only the maps
+// and arrays on the way are copied.  A missing last step is passed to change
+// as not existing, it can be added to a map by name or to an array as its
+// next element.
+func updateIn(value interface{}, steps []string, change func(old interface{}, exists bool) (interface{}, bool, error)) (interface{}, error) {
+	step, last := steps[0], len(steps) == 1
+	switch v := value.(type) {
+	case map[string]interface{}:
+		old, exists := v[step]
+		if !exists && !last {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownKey, step)
+		}
+		e, keep, err := updateStep(old, exists, steps, change)
+		if err != nil {
+			return nil, err
+		}
+		out := copyMap(v)
+		if keep {
+			out[step] = e
+		} else {
+			delete(out, step)
+		}
+		return out, nil
+	case []interface{}:
+		i, err := index(step, len(v), last)
+		if err != nil {
+			return nil, err
+		}
+		exists := i < len(v)
+		var old interface{}
+		if exists {
+			old = v[i]
+		}
+		e, keep, err := updateStep(old, exists, steps, change)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]interface{}, 0, len(v)+1)
+		out = append(out, v[:i]...)
+		if keep {
+			out = append(out, e)
+		}
+		if exists {
+			out = append(out, v[i+1:]...)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("%w: a %T has no %v", ErrWrongType, value, step)
+}
+
+func updateStep(old interface{}, exists bool, steps []string, change func(old interface{}, exists bool) (interface{}, bool, error)) (interface{}, bool, error) {
+	if len(steps) == 1 {
+		e, keep, err := change(old, exists)
+		if err == nil && !keep && !exists {
+			err = fmt.Errorf("%w: %v", ErrUnknownKey, steps[0])
+		}
+		return e, keep, err
+	}
+	e, err := updateIn(old, steps[1:], change)
+	return e, true, err
+}
+
+// withRoot is d holding root, kept the way the value it replaces was.
+func (d data) withRoot(root interface{}) data {
+	if avalue, ok := root.([]interface{}); ok && d.value == nil {
+		return d.with(nil, avalue)
+	}
+	return d.with(root, nil)
+}
+
+// nearest returns the value of the part at steps, or of the nearest part
+// above it, in parts, or key if none of them have one.
+func nearest(parts map[string]string, steps []string, key string) string {
+	for i := len(steps); i > 0; i-- {
+		if v, ok := parts[joinPath(steps[:i])]; ok {
+			return v
+		}
+	}
+	return key
+}
+
+// canSeeAt is true if role can see the part at steps of d.
+func (s *sharedData) canSeeAt(d data, steps []string, role string) bool {
+	if !s.members.canSee(d.visibility, role) {
+		return false
+	}
+	for i := 1; i <= len(steps); i++ {
+		if v, ok := d.visibilities[joinPath(steps[:i])]; ok && !s.members.canSee(v, role) {
+			return false
+		}
+	}
+	return true
+}
+
+// hideFrom returns value, the part at steps of d, with the parts below it
+// that role can't see replaced by nil.
+func (s *sharedData) hideFrom(d data, steps []string, value interface{}, role string) interface{} {
+	prefix := joinPath(steps)
+	for path := range d.visibilities {
+		rest, ok := below(path, prefix)
+		if !ok || len(rest) == 0 || s.canSeeAt(d, parseSteps(path), role) {
+			continue
+		}
+		hidden, err := updateIn(value, rest, func(old interface{}, exists bool) (interface{}, bool, error) {
+			return nil, exists, nil
+		})
+		if err == nil {
+			value = hidden
+		}
+	}
+	return value
+}
+
+// checkPathOwner returns an error unless originator may write the part at
+// steps of key, and every part below it that has an owner of its own.
+func (s *sharedData) checkPathOwner(originator member, key string, steps []string) error {
+	d, ok := s.data[key]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownKey, key)
+	}
+	path := joinPath(append([]string{key}, steps...))
+	if owner := nearest(d.owners, steps, d.owner); !s.members.includes(owner, originator.as) {
+		return fmt.Errorf("%w: %v can't write %v, it is owned by %v", ErrNotOwner, originator.as, path, owner)
+	}
+	prefix := joinPath(steps)
+	for part, owner := range d.owners {
+		if rest, ok := below(part, prefix); ok && len(rest) > 0 && !s.members.includes(owner, originator.as) {
+			return fmt.Errorf("%w: %v can't write %v, %v is owned by %v", ErrNotOwner, originator.as, path, part, owner)
+		}
+	}
+	return nil
+}
+
+// GetPath returns the part of a key at path, or nil if there isn't one or
+// we can't see it.
+func (s *sharedData) GetPath(path string) interface{} {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return nil
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	value, err := getIn(s.data[key].get(), steps)
+	if err != nil {
+		return nil
+	}
+	return copyValue(value)
+}
+
+// SetPath sets the part of a key at path, adding it if it is a new key of a
+// map or the element after the end of an array.  A path that is just a key
+// is the same as Set.
+func (s *sharedData) SetPath(path string, value interface{}) error {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.setPath(s.self(), key, steps, value)
+}
+
+func (s *sharedData) setPath(originator member, key string, steps []string, value interface{}) error {
+	if len(steps) == 0 {
+		return s.set(originator, key, value)
+	}
+	var old interface{}
+	return s.updatePath(originator, key, steps, steps, func(e interface{}, exists bool) (interface{}, bool, error) {
+		old = e
+		return value, true, nil
+	}, func() DataChange {
+		return DataChange{OldValue: old, NewValue: value, Kind: SetChange}
+	})
+}
+
+// AppendPath appends value to the array at path.
+func (s *sharedData) AppendPath(path string, value interface{}) error {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.appendPath(s.self(), key, steps, value)
+}
+
+func (s *sharedData) appendPath(originator member, key string, steps []string, value interface{}) error {
+	if len(steps) == 0 {
+		return s.appendValue(originator, key, value)
+	}
+	return s.updatePath(originator, key, steps, steps, func(e interface{}, exists bool) (interface{}, bool, error) {
+		array, ok := e.([]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("%w: %v is a %T, not an array", ErrWrongType, joinPath(steps), e)
+		}
+		return append(append([]interface{}{}, array...), value), true, nil
+	}, func() DataChange {
+		return DataChange{NewValue: value, Kind: AppendChange}
+	})
+}
+
+// DeletePath removes the part of a key at path, elements after it in an
+// array move down.  A path that is just a key is the same as Delete.
+func (s *sharedData) DeletePath(path string) error {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.deletePath(s.self(), key, steps)
+}
+
+func (s *sharedData) deletePath(originator member, key string, steps []string) error {
+	if len(steps) == 0 {
+		return s.deleteKey(originator, key)
+	}
+	// Where it was is kept in step even by members that couldn't see it
+	var old interface{}
+	return s.updatePath(originator, key, steps, steps[:len(steps)-1], func(e interface{}, exists bool) (interface{}, bool, error) {
+		old = e
+		return nil, false, nil
+	}, func() DataChange {
+		return DataChange{OldValue: old, Kind: DeleteChange}
+	})
+}
+
+// updatePath changes the part at steps of key with update, change describes
+// what was done once it has been.  Other members' changes to a part we can't
+// see, at, are only counted.
+func (s *sharedData) updatePath(originator member, key string, steps []string, at []string, update func(old interface{}, exists bool) (interface{}, bool, error), change func() DataChange) error {
+	if err := s.checkPathOwner(originator, key, steps); err != nil {
+		return err
+	}
+	d := s.data[key]
+	if originator.as != s.me && !s.canSeeAt(d, at, s.me) {
+		s.changed(DataChange{Key: key, Path: joinPath(steps), Originator: originator.contact, Kind: change().Kind})
+		return nil
+	}
+	root, err := updateIn(d.raw(), steps, update)
+	if err != nil {
+		return fmt.Errorf("%v: %w", key, err)
+	}
+	if root, err = s.conform(key, root); err != nil {
+		return err
+	}
+	s.data[key] = d.withRoot(root)
+
+	c := change()
+	c.Key, c.Path, c.Originator = key, joinPath(steps), originator.contact
+	s.changed(c)
+	return nil
+}
+
+// GetPathOwner returns who owns the part of a key at path.
+func (s *sharedData) GetPathOwner(path string) string {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return ""
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	d := s.data[key]
+	return nearest(d.owners, steps, d.owner)
+}
+
+// SetPathOwner gives the part of a key at path, and everything below it,
+// to owner.  A path that is just a key is the same as ChangeDataOwner.
+func (s *sharedData) SetPathOwner(path string, owner string) error {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.setPathOwner(s.self(), key, steps, owner)
+}
+
+func (s *sharedData) setPathOwner(originator member, key string, steps []string, owner string) error {
+	if len(steps) == 0 {
+		return s.changeDataOwner(originator, key, owner)
+	}
+	if err := s.checkPathOwner(originator, key, steps); err != nil {
+		return err
+	}
+	d := s.data[key]
+	old := nearest(d.owners, steps, d.owner)
+	d.owners = withPart(d.owners, joinPath(steps), owner)
+	s.data[key] = d
+	s.changed(DataChange{Key: key, Path: joinPath(steps), OldValue: old, NewValue: owner, Originator: originator.contact, Kind: OwnerChange})
+	return nil
+}
+
+// GetPathVisibility returns who can see the part of a key at path, as long
+// as they can see the parts above it.
+func (s *sharedData) GetPathVisibility(path string) string {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return ""
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	d := s.data[key]
+	return nearest(d.visibilities, steps, d.visibility)
+}
+
+// SetPathVisibility changes who can see the part of a key at path.  A path
+// that is just a key is the same as SetVisibility.
+func (s *sharedData) SetPathVisibility(path string, visibility string) error {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(steps) == 0 {
+		return s.setVisibility(s.self(), key, visibility, s.data[key].raw(), s.data[key].version)
+	}
+	return s.setPathVisibility(s.self(), key, steps, visibility, nil)
+}
+
+// setPathVisibility reveals value, the part at steps, to us if we can see
+// it now.
+func (s *sharedData) setPathVisibility(originator member, key string, steps []string, visibility string, value interface{}) error {
+	if err := s.checkPathOwner(originator, key, steps); err != nil {
+		return err
+	}
+	d := s.data[key]
+	old := nearest(d.visibilities, steps, d.visibility)
+	d.visibilities = withPart(d.visibilities, joinPath(steps), visibility)
+	if value != nil && s.canSeeAt(d, steps, s.me) {
+		root, err := updateIn(d.raw(), steps, func(interface{}, bool) (interface{}, bool, error) {
+			return ownCopy(value), true, nil
+		})
+		if err == nil {
+			d = d.withRoot(root)
+		}
+	}
+	s.data[key] = d
+	s.changed(DataChange{Key: key, Path: joinPath(steps), OldValue: old, NewValue: visibility, Originator: originator.contact, Kind: VisibilityChange})
+	return nil
+}
+
+// withPart returns a copy of parts with path set to value.
+func withPart(parts map[string]string, path string, value string) map[string]string {
+	out := make(map[string]string, len(parts)+1)
+	for k, v := range parts {
+		out[k] = v
+	}
+	out[path] = value
+	return out
+}
+
+// encodeFor encodes value, the part at steps of d, for each member that can
+// see it, leaving out the parts below it they can't.
+func (p *sharedDataProxy) encodeFor(codec Codec, d data, steps []string, value interface{}) (map[string][]byte, error) {
+	origin := originOf(p)
+	encoded := make(map[string][]byte)
+	for as := range p.invities {
+		if as == origin.me || !origin.canSeeAt(d, steps, as) {
+			continue
+		}
+		b, err := encode(codec, origin.hideFrom(d, steps, value, as))
+		if err != nil {
+			return nil, err
+		}
+		encoded[as] = b
+	}
+	return encoded, nil
+}
+
+// broadcastPath sends a change to a part of key to every member that can see
+// key, those that can't see the part are sent it without a value so they
+// can count it.
+func (p *sharedDataProxy) broadcastPath(uri string, key string, msgFor func(as string) proto.Message, resp proto.Message) *delivery {
+	origin := originOf(p)
+	visibility := origin.data[key].visibility
+	return p.broadcastEach(uri, func(as string) proto.Message {
+		if !origin.members.canSee(visibility, as) {
+			return nil
+		}
+		return msgFor(as)
+	}, resp)
+}
+
+func (p *sharedDataProxy) GetPath(path string) interface{} {
+	return p.origin.GetPath(path)
+}
+
+func (p *sharedDataProxy) SetPath(path string, value interface{}) error {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		return p.Set(key, value)
+	}
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		encoded, err := p.encodeFor(codec, origin.data[key], steps, value)
+		if err != nil {
+			return nil, err
+		}
+		if err := origin.setPath(origin.self(), key, steps, value); err != nil {
+			return nil, err
+		}
+
+		req := pb.SharedDataSet{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Path:         joinPath(steps),
+			Codec:        codec.Id(),
+		}
+		resp := pb.SharedDataSetResponse{}
+		return p.broadcastPath("/shareddata/set", key, func(as string) proto.Message {
+			msg := proto.Clone(&req).(*pb.SharedDataSet)
+			msg.Value = encoded[as]
+			return msg
+		}, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) AppendPath(path string, value interface{}) error {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		return p.Append(key, value)
+	}
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		d := origin.data[key]
+		array, _ := getIn(d.raw(), steps)
+		elements, _ := array.([]interface{})
+		element := append(append([]string{}, steps...), strconv.Itoa(len(elements)))
+		encoded, err := p.encodeFor(codec, d, element, value)
+		if err != nil {
+			return nil, err
+		}
+		if err := origin.appendPath(origin.self(), key, steps, value); err != nil {
+			return nil, err
+		}
+
+		req := pb.SharedDataAppend{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Path:         joinPath(steps),
+			Codec:        codec.Id(),
+		}
+		resp := pb.SharedDataAppendResponse{}
+		return p.broadcastPath("/shareddata/append", key, func(as string) proto.Message {
+			msg := proto.Clone(&req).(*pb.SharedDataAppend)
+			msg.Value = encoded[as]
+			return msg
+		}, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) DeletePath(path string) error {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		return p.Delete(key)
+	}
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.deletePath(origin.self(), key, steps); err != nil {
+			return nil, err
+		}
+
+		req := pb.SharedDataDelete{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Path:         joinPath(steps),
+		}
+		resp := pb.SharedDataDeleteResponse{}
+		return p.broadcastPath("/shareddata/delete", key, func(as string) proto.Message {
+			return &req
+		}, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) GetPathOwner(path string) string {
+	return p.origin.GetPathOwner(path)
+}
+
+func (p *sharedDataProxy) SetPathOwner(path string, owner string) error {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		return p.ChangeDataOwner(key, owner)
+	}
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.setPathOwner(origin.self(), key, steps, owner); err != nil {
+			return nil, err
+		}
+
+		req := pb.SharedDataChangeOwner{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Path:         joinPath(steps),
+			Owner:        owner,
+		}
+		resp := pb.SharedDataChangeOwnerResponse{}
+		return p.broadcast("/shareddata/changeowner", "", &req, nil, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) GetPathVisibility(path string) string {
+	return p.origin.GetPathVisibility(path)
+}
+
+func (p *sharedDataProxy) SetPathVisibility(path string, visibility string) error {
+	key, steps, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		return p.SetVisibility(key, visibility)
+	}
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		d := origin.data[key]
+		value, _ := getIn(d.raw(), steps)
+		after := d
+		after.visibilities = withPart(d.visibilities, joinPath(steps), visibility)
+		encoded, err := p.encodeFor(codec, after, steps, value)
+		if err != nil {
+			return nil, err
+		}
+		if err := origin.setPathVisibility(origin.self(), key, steps, visibility, nil); err != nil {
+			return nil, err
+		}
+
+		req := pb.SharedDataSetVisibility{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Path:         joinPath(steps),
+			Visibility:   visibility,
+			Codec:        codec.Id(),
+		}
+		resp := pb.SharedDataSetVisibilityResponse{}
+		// Members that can't see it still learn who can
+		return p.broadcastEach("/shareddata/setvisibility", func(as string) proto.Message {
+			msg := proto.Clone(&req).(*pb.SharedDataSetVisibility)
+			msg.Value = encoded[as]
+			return msg
+		}, &resp), nil
+	})
+}
@@ -0,0 +1,163 @@
+package shareddata
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Change is what Watch sends.  The first has the Snapshot of the watched
+// keys, as they were when the watch started, and every one after is a
+// DataChange to one of them, in the order they were made.
+type Change struct {
+	DataChange
+	Snapshot map[string]interface{}
+}
+
+// watcher queues the changes for a Watch so a slow reader doesn't hold up
+// the other listeners.
+type watcher struct {
+	lock   sync.Mutex
+	queue  []Change
+	wake   chan struct{}
+	done   chan struct{}
+	ch     chan Change
+	remove func()
+}
+
+func newWatcher() *watcher {
+	w := &watcher{wake: make(chan struct{}, 1), done: make(chan struct{}), ch: make(chan Change)}
+	go w.pump()
+	return w
+}
+
+func (w *watcher) push(change Change) {
+	w.lock.Lock()
+	w.queue = append(w.queue, change)
+	w.lock.Unlock()
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher) pump() {
+	defer close(w.ch)
+	for {
+		w.lock.Lock()
+		if len(w.queue) == 0 {
+			w.lock.Unlock()
+			select {
+			case <-w.wake:
+				continue
+			case <-w.done:
+				return
+			}
+		}
+		change := w.queue[0]
+		w.queue = w.queue[1:]
+		w.lock.Unlock()
+
+		select {
+		case w.ch <- change:
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// keys returns the keys starting with prefix, sorted.
+func (s *sharedData) keys(prefix string) []string {
+	keys := []string{}
+	for key := range s.data {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Keys returns the keys starting with prefix, sorted, including those whose
+// values we can't see.
+func (s *sharedData) Keys(prefix string) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.keys(prefix)
+}
+
+// Range calls fn with a copy of each key starting with prefix, in the order
+// of Keys, until it returns false.  The values are taken together before fn
+// is called, so fn may change s.
+func (s *sharedData) Range(prefix string, fn func(key string, value interface{}) bool) {
+	s.lock.RLock()
+	keys := s.keys(prefix)
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		values[i] = copyValue(s.data[key].get())
+	}
+	s.lock.RUnlock()
+
+	for i, key := range keys {
+		if !fn(key, values[i]) {
+			return
+		}
+	}
+}
+
+// Watch returns a channel sent the keys starting with prefix and then each
+// change made to them, until Unwatch is called with it.
+func (s *sharedData) Watch(prefix string) <-chan Change {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	snapshot := make(map[string]interface{})
+	for _, key := range s.keys(prefix) {
+		snapshot[key] = copyValue(s.data[key].get())
+	}
+	w := newWatcher()
+	w.push(Change{Snapshot: snapshot})
+	// Changes are only made with the lock held, so none can come between
+	// the snapshot and the listener
+	w.remove = s.listeners.add(func(change DataChange) {
+		if change.Kind != OwnershipRequest && strings.HasPrefix(change.Key, prefix) {
+			w.push(Change{DataChange: change})
+		}
+	})
+	if s.watchers == nil {
+		s.watchers = make(map[<-chan Change]*watcher)
+	}
+	s.watchers[w.ch] = w
+	return w.ch
+}
+
+// Unwatch stops the watch that returned ch and closes it, changes not yet
+// received are dropped.
+func (s *sharedData) Unwatch(ch <-chan Change) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	w, ok := s.watchers[ch]
+	if !ok {
+		return
+	}
+	delete(s.watchers, ch)
+	w.remove()
+	close(w.done)
+}
+
+func (p *sharedDataProxy) Keys(prefix string) []string {
+	return p.origin.Keys(prefix)
+}
+
+func (p *sharedDataProxy) Range(prefix string, fn func(key string, value interface{}) bool) {
+	p.origin.Range(prefix, fn)
+}
+
+func (p *sharedDataProxy) Watch(prefix string) <-chan Change {
+	return p.origin.Watch(prefix)
+}
+
+func (p *sharedDataProxy) Unwatch(ch <-chan Change) {
+	p.origin.Unwatch(ch)
+}
@@ -0,0 +1,214 @@
+package shareddata
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Schema describes the keys a shared data holds.  Once it is set every
+// change, local or remote, has to fit it or is refused with ErrWrongType or
+// ErrInvalid.  Validators are code so a schema can't be sent to other
+// members, each registers the schemas it knows with RegisterSchema and only
+// joins shares whose schema it has at the same version.
+type Schema struct {
+	Name    string
+	Version int
+	Keys    map[string]KeySchema
+	// Strict refuses keys that aren't in Keys
+	Strict bool
+}
+
+// KeySchema describes a key of a Schema.
+type KeySchema struct {
+	// Type is what the value must be, as with Key, or anything if nil
+	Type reflect.Type
+	// Owner and Visibility are used when the key is created without them
+	Owner      string
+	Visibility string
+	// Validators check the value, or each element if it is an array or map
+	Validators []Validator
+}
+
+// Validator returns why value isn't allowed, or nil if it is.
+type Validator func(value interface{}) error
+
+// TypeOf is the reflect.Type of T, for KeySchema.Type.
+func TypeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// InRange allows numbers from min to max.
+func InRange(min float64, max float64) Validator {
+	return func(value interface{}) error {
+		rv := reflect.ValueOf(value)
+		if !isNumber(rv.Kind()) {
+			return fmt.Errorf("a %T is not a number", value)
+		}
+		n := rv.Convert(reflect.TypeOf(float64(0))).Float()
+		if n < min || n > max {
+			return fmt.Errorf("%v is not between %v and %v", value, min, max)
+		}
+		return nil
+	}
+}
+
+// OneOf allows only values.
+func OneOf(values ...interface{}) Validator {
+	return func(value interface{}) error {
+		for _, v := range values {
+			if reflect.DeepEqual(v, value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%v is not one of %v", value, values)
+	}
+}
+
+func (schema *Schema) String() string {
+	return fmt.Sprintf("%v version %d", schema.Name, schema.Version)
+}
+
+// allows returns ErrInvalid if a strict schema doesn't have key.
+func (schema *Schema) allows(key string) error {
+	if schema == nil || !schema.Strict {
+		return nil
+	}
+	if _, ok := schema.Keys[key]; !ok {
+		return fmt.Errorf("%w: %v has no key %v", ErrInvalid, schema, key)
+	}
+	return nil
+}
+
+// defaults fills in the owner and visibility of key if they are empty.
+func (schema *Schema) defaults(key string, owner string, visibility string) (string, string) {
+	if schema == nil {
+		return owner, visibility
+	}
+	ks := schema.Keys[key]
+	if owner == "" {
+		owner = ks.Owner
+	}
+	if visibility == "" {
+		visibility = ks.Visibility
+	}
+	return owner, visibility
+}
+
+// validate runs the validators of key on value, or on each of its elements
+// if it is an array or map.  Values we can't see and crdts aren't checked.
+func (schema *Schema) validate(key string, value interface{}) error {
+	switch v := value.(type) {
+	case nil, crdt:
+		return nil
+	case []interface{}:
+		for _, e := range v {
+			if err := schema.validateElem(key, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		for _, e := range v {
+			if err := schema.validateElem(key, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return schema.validateElem(key, value)
+}
+
+// validateElem runs the validators of key on value alone.
+func (schema *Schema) validateElem(key string, value interface{}) error {
+	if schema == nil {
+		return nil
+	}
+	for _, validator := range schema.Keys[key].Validators {
+		if err := validator(value); err != nil {
+			return fmt.Errorf("%w: %v: %v", ErrInvalid, key, err)
+		}
+	}
+	return nil
+}
+
+// SetSchema has s check every change against schema, it fails if a key s
+// already holds doesn't fit.  The types of keys in the schema replace any
+// declared with Key, ArrayKey or MapKey.  A nil schema stops the checks and
+// forgets the types of keys.
+func (s *sharedData) SetSchema(schema *Schema) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.setSchema(schema)
+}
+
+func (s *sharedData) setSchema(schema *Schema) error {
+	if schema == nil {
+		s.schema, s.types = nil, nil
+		return nil
+	}
+	oldSchema, oldTypes := s.schema, s.types
+	s.schema = schema
+	s.types = make(map[string]reflect.Type, len(oldTypes)+len(schema.Keys))
+	for key, t := range oldTypes {
+		s.types[key] = t
+	}
+	for key, ks := range schema.Keys {
+		if ks.Type != nil {
+			s.types[key] = ks.Type
+		}
+	}
+
+	for key, d := range s.data {
+		if _, err := s.conform(key, d.raw()); err != nil {
+			s.schema, s.types = oldSchema, oldTypes
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSchema returns the schema set with SetSchema, or nil.
+func (s *sharedData) GetSchema() *Schema {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.schema
+}
+
+// RegisterSchema lets us join shares with schema.  Shares with a schema we
+// don't have, or have a different version of, are refused with
+// ErrIncompatibleSchema.
+func (sdm *sharedDataManager) RegisterSchema(schema *Schema) {
+	sdm.configLock.Lock()
+	defer sdm.configLock.Unlock()
+	if sdm.schemas == nil {
+		sdm.schemas = make(map[string]*Schema)
+	}
+	sdm.schemas[schema.Name] = schema
+}
+
+// schemaFor returns our copy of the schema a share says it has, nil if it
+// has none.
+func (sdm *sharedDataManager) schemaFor(name string, version int32) (*Schema, error) {
+	if name == "" {
+		return nil, nil
+	}
+	sdm.configLock.RLock()
+	defer sdm.configLock.RUnlock()
+	schema, ok := sdm.schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: we don't have %v version %d", ErrIncompatibleSchema, name, version)
+	}
+	if schema.Version != int(version) {
+		return nil, fmt.Errorf("%w: we have %v, not version %d", ErrIncompatibleSchema, schema, version)
+	}
+	return schema, nil
+}
+
+// schemaOf returns the name and version of the schema of s to send to
+// other members.
+func schemaOf(s *sharedData) (string, int32) {
+	if s.schema == nil {
+		return "", 0
+	}
+	return s.schema.Name, int32(s.schema.Version)
+}
@@ -1,7 +1,10 @@
 package shareddata
 
 import (
+	"fmt"
 	"reflect"
+	"sync"
+	"time"
 
 	"github.com/hoyle1974/grapevine/common"
 )
@@ -22,26 +25,71 @@ type ClientCallback interface {
 	OnSearchResult(id SearchId, result string, contact common.Contact)
 	OnInvited(sharedDataId SharedDataId, me string, contact common.Contact) bool
 	OnInviteAccepted(sharedData SharedData, contact common.Contact)
+	OnInviteReceived(invite Invitation)
+	OnInviteDeclined(invite Invitation, reason string)
 	OnSharedDataAvailable(sharedData SharedData)
+	OnHostChanged(sharedData SharedData, host string, contact common.Contact)
 }
 
+// SharedData is a set of keys shared between members.  Its mutators return
+// an error since v0.2.0, which broke implementations made before it, see
+// CHANGELOG.md.
 type SharedData interface {
 	IsProxy() bool
 	GetCreator() common.Contact
+	GetHost() string
 	GetId() SharedDataId
-	Create(key string, value interface{}, owner string, visibility string)
-	CreateArray(key string, value []interface{}, owner string, visibility string)
-	CreateMap(key string, value interface{}, owner string, visibility string)
+	Create(key string, value interface{}, owner string, visibility string) error
+	CreateArray(key string, value []interface{}, owner string, visibility string) error
+	CreateMap(key string, value interface{}, owner string, visibility string) error
+	CreateCRDT(key string, kind CRDTKind, owner string, visibility string) error
 	Get(key string) interface{}
-	Set(key string, value interface{})
-	SetMap(key string, mapKey string, value interface{})
-	Append(key string, value interface{})
+	Keys(prefix string) []string
+	Range(prefix string, fn func(key string, value interface{}) bool)
+	Watch(prefix string) <-chan Change
+	Unwatch(ch <-chan Change)
+	Set(key string, value interface{}) error
+	GetVersioned(key string) (interface{}, uint64)
+	CompareAndSet(key string, version uint64, value interface{}) error
+	SetIf(key string, value interface{}, predicate func(current interface{}) bool) error
+	SetMap(key string, mapKey string, value interface{}) error
+	Append(key string, value interface{}) error
+	InsertAt(key string, index int, value interface{}) error
+	RemoveAt(key string, index int) error
+	Splice(key string, index int, count int, values ...interface{}) error
+	DeleteMapKey(key string, mapKey string) error
+	Delete(key string) error
+	Len(key string) int
+	Transaction(f func(tx Tx) error) error
+	History(key string, since time.Time) []HistoryEntry
+	Undo(id OpId) error
+	Increment(key string, delta int64) error
+	AddToSet(key string, value interface{}) error
+	RemoveFromSet(key string, value interface{}) error
 	GetOwner(key string) string
 	SetMe(string)
 	GetMe() string
 	IsMe(string) bool
-	OnDataChangeCB(func(key string))
-	ChangeDataOwner(key string, owner string)
+	OnDataChangeCB(func(change DataChange)) func()
+	ChangeDataOwner(key string, owner string) error
+	DefineGroup(group string, roles []string) error
+	GetGroup(group string) []string
+	SetVisibility(key string, visibility string) error
+	GetVisibility(key string) string
+	SetSuccessor(key string, successor string) error
+	GetSuccessor(key string) string
+	RequestOwnership(key string) error
+	GrantOwnership(key string, to string) error
+	GetPath(path string) interface{}
+	SetPath(path string, value interface{}) error
+	AppendPath(path string, value interface{}) error
+	DeletePath(path string) error
+	GetPathOwner(path string) string
+	SetPathOwner(path string, owner string) error
+	GetPathVisibility(path string) string
+	SetPathVisibility(path string, visibility string) error
+	SetSchema(schema *Schema) error
+	GetSchema() *Schema
 	GetData() map[string]data
 }
 
@@ -50,143 +98,731 @@ type data struct {
 	avalue     []interface{}
 	owner      string
 	visibility string
+	version    uint64 // How many times the key has changed
+	successor  string // Who takes over the key if the owner's lease expires
+	// Owners and visibilities of parts of the value, by their path below the key
+	owners       map[string]string
+	visibilities map[string]string
 }
 
+// with is d holding value and avalue instead.
+func (d data) with(value interface{}, avalue []interface{}) data {
+	d.value, d.avalue = value, avalue
+	return d
+}
+
+// sharedData is safe to use from several goroutines.  Its exported methods
+// take the lock and the unexported ones expect it to be held, the proxy in
+// front of it shares the lock so local and remote changes are made one at a
+// time.  Listeners are called without it.
 type sharedData struct {
-	creator common.Contact
-	id      SharedDataId
-	me      string
-	data    map[string]data
-	cb      func(key string)
+	lock      sync.RWMutex
+	creator   common.Contact
+	host      string // The role of the creator, or whoever took over from it
+	hostAt    common.Contact
+	contacts  map[string]common.Contact // The members as of the last state we were sent, to rejoin through
+	local     common.Contact
+	id        SharedDataId
+	me        string
+	data      map[string]data
+	members   membership
+	listeners changeListeners
+	clock     hlc
+	history   history
+	types     map[string]reflect.Type // Declared with Key, ArrayKey and MapKey, or by the schema
+	schema    *Schema
+	watchers  map[<-chan Change]*watcher
 }
 
 func NewSharedData(creator common.Contact, id SharedDataId) SharedData {
-	return &sharedData{id: id, creator: creator, data: make(map[string]data)}
+	s := &sharedData{id: id, creator: creator, hostAt: creator, local: creator, data: make(map[string]data)}
+	s.listeners.addJournal(s.history.record)
+	return s
 }
 
+// GetData returns a copy of every key, later changes don't touch it.
 func (s *sharedData) GetData() map[string]data {
-	return s.data
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.snapshot()
 }
 
 func (s *sharedData) IsProxy() bool {
 	return false
 }
 
+// GetCreator returns the member that created the shared data, which hosts
+// it until it leaves and another member takes over, see GetHost.
 func (s *sharedData) GetCreator() common.Contact {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	return s.creator
 }
 
+// hostContact returns where the host of the shared data can be reached.
+func (s *sharedData) hostContact() common.Contact {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.hostAt
+}
+
+// GetId needs no lock, the id never changes.
 func (s *sharedData) GetId() SharedDataId {
 	return s.id
 }
 
-func (s *sharedData) Create(key string, value interface{}, owner string, visibility string) {
-	s.data[key] = data{value, nil, owner, visibility}
+func (s *sharedData) Create(key string, value interface{}, owner string, visibility string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.create(s.self(), key, value, owner, visibility)
+}
+
+// checkCreate returns an error if key already exists and originator may not
+// replace it.
+func (s *sharedData) checkCreate(originator member, key string) error {
+	if _, exists := s.data[key]; !exists {
+		return nil
+	}
+	return s.checkOwner(originator, key)
+}
+
+func (s *sharedData) create(originator member, key string, value interface{}, owner string, visibility string) error {
+	if err := s.checkCreate(originator, key); err != nil {
+		return err
+	}
+	value, err := s.conform(key, value)
+	if err != nil {
+		return err
+	}
+	owner, visibility = s.schema.defaults(key, owner, visibility)
+	s.load(originator, key, data{value: value, owner: owner, visibility: visibility})
+	return nil
+}
+
+// load stores d under key without any checks, it is used for changes that
+// were already accepted, such as the state sent to a new member.  Unless d
+// says which version it is it counts as a change to what it replaces.
+func (s *sharedData) load(originator member, key string, d data) {
+	old := s.data[key]
+	d.value = ownCopy(d.value)
+	if d.version == 0 {
+		d.version = old.version + 1
+	}
+	s.data[key] = d
+	value := d.get()
+	if d.value == nil && d.avalue == nil {
+		value = nil
+	}
+	s.listeners.notify(DataChange{Key: key, OldValue: old.get(), NewValue: value, Originator: originator.contact, Kind: CreateChange, Version: d.version, Visibility: d.visibility})
+}
+
+// changed counts change as a new version of its key and tells the
+// listeners about it.
+func (s *sharedData) changed(change DataChange) {
+	if d, ok := s.data[change.Key]; ok {
+		d.version++
+		s.data[change.Key] = d
+		change.Version = d.version
+		change.Visibility = nearest(d.visibilities, parseSteps(change.Path), d.visibility)
+	}
+	s.listeners.notify(change)
+}
+
+// newData stores value the way it was created, arrays are kept apart so
+// they can be appended to.
+func newData(value interface{}, owner string, visibility string) data {
+	if avalue, ok := value.([]interface{}); ok {
+		return data{avalue: avalue, owner: owner, visibility: visibility}
+	}
+	return data{value: value, owner: owner, visibility: visibility}
+}
+
+func (s *sharedData) CreateArray(key string, value []interface{}, owner string, visibility string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.createArray(s.self(), key, value, owner, visibility)
+}
+
+func (s *sharedData) createArray(originator member, key string, value []interface{}, owner string, visibility string) error {
+	if err := s.checkCreate(originator, key); err != nil {
+		return err
+	}
+	v, err := s.conform(key, value)
+	if err != nil {
+		return err
+	}
+	value, _ = v.([]interface{})
+	owner, visibility = s.schema.defaults(key, owner, visibility)
+	s.load(originator, key, data{avalue: value, owner: owner, visibility: visibility})
+	return nil
 }
 
-func (s *sharedData) CreateArray(key string, value []interface{}, owner string, visibility string) {
-	s.data[key] = data{nil, value, owner, visibility}
+func (s *sharedData) CreateMap(key string, value interface{}, owner string, visibility string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.createMap(s.self(), key, value, owner, visibility)
 }
 
-func (s *sharedData) CreateMap(key string, value interface{}, owner string, visibility string) {
+func (s *sharedData) createMap(originator member, key string, value interface{}, owner string, visibility string) error {
+	if err := s.checkCreate(originator, key); err != nil {
+		return err
+	}
 
 	temp := make(map[string]interface{})
 
 	val := reflect.ValueOf(value)
 
 	if val.Kind() != reflect.Map {
-		panic("not a map!")
+		return fmt.Errorf("%w: %v is a %T, not a map", ErrWrongType, key, value)
 	}
 
 	for _, e := range val.MapKeys() {
-		v := val.MapIndex(e)
-		temp[e.String()] = v
+		temp[fmt.Sprint(e.Interface())] = val.MapIndex(e).Interface()
+	}
+
+	conformed, err := s.conform(key, temp)
+	if err != nil {
+		return err
+	}
+	owner, visibility = s.schema.defaults(key, owner, visibility)
+	s.load(originator, key, data{value: conformed, owner: owner, visibility: visibility})
+	return nil
+}
+
+// CreateCRDT creates key as an empty conflict-free replicated data type of
+// kind.  Changes to it from different members are merged, so it is
+// usually owned by a group rather than a single member.
+func (s *sharedData) CreateCRDT(key string, kind CRDTKind, owner string, visibility string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.createCRDT(s.self(), key, kind, owner, visibility)
+}
+
+func (s *sharedData) createCRDT(originator member, key string, kind CRDTKind, owner string, visibility string) error {
+	c, err := newCRDT(kind)
+	if err != nil {
+		return err
+	}
+	return s.create(originator, key, c, owner, visibility)
+}
+
+// crdtOf returns the crdt behind key, or nil if it isn't one.
+func (s *sharedData) crdtOf(key string) crdt {
+	c, _ := s.data[key].value.(crdt)
+	return c
+}
+
+// updateCRDT checks originator may change key, which must be a crdt, and
+// then lets change update it.
+func (s *sharedData) updateCRDT(originator member, key string, kind ChangeKind, change func(c crdt) error) error {
+	if err := s.checkOwner(originator, key); err != nil {
+		return err
 	}
+	c := s.crdtOf(key)
+	if c == nil {
+		return fmt.Errorf("%w: %v is not a crdt", ErrWrongType, key)
+	}
+	old := c.value()
+	if err := change(c); err != nil {
+		return err
+	}
+	s.changed(DataChange{Key: key, OldValue: old, NewValue: c.value(), Originator: originator.contact, Kind: kind})
+	return nil
+}
+
+func (s *sharedData) Increment(key string, delta int64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.increment(s.self(), key, delta)
+}
+
+func (s *sharedData) increment(originator member, key string, delta int64) error {
+	return s.updateCRDT(originator, key, MergeChange, func(c crdt) error {
+		switch counter := c.(type) {
+		case *gCounter:
+			if delta < 0 {
+				return fmt.Errorf("%w: %v can only be incremented", ErrWrongType, key)
+			}
+			counter.increment(originator.as, uint64(delta))
+		case *pnCounter:
+			counter.increment(originator.as, delta)
+		default:
+			return fmt.Errorf("%w: %v is a %v, not a counter", ErrWrongType, key, c.kind())
+		}
+		return nil
+	})
+}
+
+func (s *sharedData) AddToSet(key string, value interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.addToSet(s.self(), key, value)
+}
+
+func (s *sharedData) addToSet(originator member, key string, value interface{}) error {
+	return s.updateCRDT(originator, key, MergeChange, func(c crdt) error {
+		set, ok := c.(*orSet)
+		if !ok {
+			return fmt.Errorf("%w: %v is a %v, not a set", ErrWrongType, key, c.kind())
+		}
+		set.add(value, s.clock.now(originator.as))
+		return nil
+	})
+}
+
+func (s *sharedData) RemoveFromSet(key string, value interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.removeFromSet(s.self(), key, value)
+}
+
+func (s *sharedData) removeFromSet(originator member, key string, value interface{}) error {
+	return s.updateCRDT(originator, key, MergeChange, func(c crdt) error {
+		set, ok := c.(*orSet)
+		if !ok {
+			return fmt.Errorf("%w: %v is a %v, not a set", ErrWrongType, key, c.kind())
+		}
+		set.remove(value)
+		return nil
+	})
+}
+
+// merge folds other, another member's copy of the crdt key, into ours.
+func (s *sharedData) merge(originator member, key string, other crdt) error {
+	return s.updateCRDT(originator, key, MergeChange, func(c crdt) error {
+		return s.mergeCRDT(c, other)
+	})
+}
+
+func (s *sharedData) mergeCRDT(c crdt, other crdt) error {
+	if err := c.merge(other); err != nil {
+		return err
+	}
+	s.clock.observe(other.latest())
+	return nil
+}
 
-	s.data[key] = data{temp, nil, owner, visibility}
+func (s *sharedData) Set(key string, value interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.set(s.self(), key, value)
 }
 
-func (s *sharedData) Set(key string, value interface{}) {
+func (s *sharedData) set(originator member, key string, value interface{}) error {
 	// fmt.Printf("Set %v:%v \n", key, value)
-	d, ok := s.data[key]
-	if !ok {
-		return
+	if err := s.checkOwner(originator, key); err != nil {
+		return err
+	}
+	d := s.data[key]
+	value, err := s.conform(key, value)
+	if err != nil {
+		return err
+	}
+	if c, ok := d.value.(crdt); ok {
+		register, ok := c.(*lwwRegister)
+		if !ok {
+			return fmt.Errorf("%w: %v is a %v, not a register", ErrWrongType, key, c.kind())
+		}
+		old := register.value()
+		register.set(value, s.clock.now(originator.as))
+		s.changed(DataChange{Key: key, OldValue: old, NewValue: register.value(), Originator: originator.contact, Kind: SetChange})
+		return nil
+	}
+	// Arrays are kept apart, as newData does, so they can be appended to
+	if avalue, ok := value.([]interface{}); ok {
+		s.data[key] = d.with(nil, avalue)
+	} else {
+		s.data[key] = d.with(value, nil)
+	}
+	s.changed(DataChange{Key: key, OldValue: d.get(), NewValue: value, Originator: originator.contact, Kind: SetChange})
+	return nil
+}
+
+// GetVersioned returns the value of key along with its version, which goes
+// up every time key changes.  Versions are only kept by members that can see
+// key.
+func (s *sharedData) GetVersioned(key string) (interface{}, uint64) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	d := s.data[key]
+	return copyValue(d.get()), d.version
+}
+
+// CompareAndSet sets key to value only if it is still at version, otherwise
+// it returns ErrConflict.  Every member that can see key checks the version
+// again before applying it.
+func (s *sharedData) CompareAndSet(key string, version uint64, value interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.compareAndSet(s.self(), key, version, value)
+}
+
+func (s *sharedData) compareAndSet(originator member, key string, version uint64, value interface{}) error {
+	if err := s.checkOwner(originator, key); err != nil {
+		return err
+	}
+	d := s.data[key]
+	if c, ok := d.value.(crdt); ok {
+		return fmt.Errorf("%w: %v is a %v, changes to it are merged", ErrWrongType, key, c.kind())
+	}
+	if d.version != version {
+		return fmt.Errorf("%w: %v is at version %d, not %d", ErrConflict, key, d.version, version)
+	}
+	return s.set(originator, key, value)
+}
+
+// SetIf sets key to value only if predicate accepts its current value,
+// otherwise it returns ErrConflict.  predicate is called with the lock held,
+// so it is handed a copy of the value and mustn't use the SharedData.
+func (s *sharedData) SetIf(key string, value interface{}, predicate func(current interface{}) bool) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	d := s.data[key]
+	if !predicate(copyValue(d.get())) {
+		return fmt.Errorf("%w: %v", ErrConflict, key)
 	}
-	// if s.IsMe(d.owner) {
-	s.data[key] = data{value, nil, d.owner, d.visibility}
-	// }
+	return s.compareAndSet(s.self(), key, d.version, value)
 }
 
-func (s *sharedData) SetMap(key string, mapKey string, value interface{}) {
+func (s *sharedData) SetMap(key string, mapKey string, value interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.setMap(s.self(), key, mapKey, value)
+}
+
+func (s *sharedData) setMap(originator member, key string, mapKey string, value interface{}) error {
 	// fmt.Printf("Set %v:%v \n", key, value)
-	d, ok := s.data[key]
-	if !ok {
-		return
+	if err := s.checkOwner(originator, key); err != nil {
+		return err
+	}
+	d := s.data[key]
+	value, err := s.conformElem(key, value)
+	if err != nil {
+		return err
+	}
+	if m, ok := d.value.(*orMap); ok {
+		old := m.get(mapKey)
+		m.set(mapKey, value, s.clock.now(originator.as))
+		s.changed(DataChange{Key: key, MapKey: mapKey, OldValue: old, NewValue: m.get(mapKey), Originator: originator.contact, Kind: SetMapChange})
+		return nil
 	}
-	// if s.IsMe(d.owner) {
 	dd, ok := d.value.(map[string]interface{})
 	if !ok {
-		panic("Unexpected type")
+		return fmt.Errorf("%w: %v is not a map", ErrWrongType, key)
 	}
+	// The map may have been handed to listeners, so it is copied rather
+	// than changed in place
+	old := dd[mapKey]
+	dd = copyMap(dd)
 	dd[mapKey] = value
 
-	s.data[key] = data{dd, nil, d.owner, d.visibility}
-	// }
+	s.data[key] = d.with(dd, nil)
+	s.changed(DataChange{Key: key, MapKey: mapKey, OldValue: old, NewValue: value, Originator: originator.contact, Kind: SetMapChange})
+	return nil
 }
 
+// Get returns a copy of the value of key.
 func (s *sharedData) Get(key string) interface{} {
-	t := s.data[key]
-	if t.value == nil {
-		return t.avalue
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return copyValue(s.data[key].get())
+}
+
+func (d data) get() interface{} {
+	if c, ok := d.value.(crdt); ok {
+		return c.value()
 	}
-	return t.value
+	if d.value == nil {
+		return d.avalue
+	}
+	return d.value
+}
+
+// raw is what is stored for d, unlike get it doesn't turn crdts into their values.
+func (d data) raw() interface{} {
+	if d.value == nil {
+		return d.avalue
+	}
+	return d.value
+}
+
+func (s *sharedData) Append(key string, value interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.appendValue(s.self(), key, value)
 }
 
-func (s *sharedData) Append(key string, value interface{}) {
+func (s *sharedData) appendValue(originator member, key string, value interface{}) error {
 	// fmt.Printf("%v) Append %v:%v \n", s.id, key, value)
+	if err := s.checkOwner(originator, key); err != nil {
+		return err
+	}
+	d := s.data[key]
+	value, err := s.conformElem(key, value)
+	if err != nil {
+		return err
+	}
+	if l, ok := d.value.(*rgaList); ok {
+		l.append(value, s.clock.now(originator.as))
+		s.changed(DataChange{Key: key, NewValue: value, Originator: originator.contact, Kind: AppendChange})
+		return nil
+	}
+	if c, ok := d.value.(crdt); ok {
+		return fmt.Errorf("%w: %v is a %v, not a list", ErrWrongType, key, c.kind())
+	}
+	d.avalue = append(d.avalue, value)
+	s.data[key] = d.with(nil, d.avalue)
+	s.changed(DataChange{Key: key, NewValue: value, Originator: originator.contact, Kind: AppendChange})
+	return nil
+}
+
+func (s *sharedData) InsertAt(key string, index int, value interface{}) error {
+	return s.Splice(key, index, 0, value)
+}
+
+func (s *sharedData) RemoveAt(key string, index int) error {
+	return s.Splice(key, index, 1)
+}
+
+// Splice removes count elements of the array key, starting at index, and
+// inserts values in their place.
+func (s *sharedData) Splice(key string, index int, count int, values ...interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.splice(s.self(), key, index, count, values)
+}
+
+func (s *sharedData) splice(originator member, key string, index int, count int, values []interface{}) error {
+	if err := s.checkOwner(originator, key); err != nil {
+		return err
+	}
+	d := s.data[key]
+	if len(values) > 0 {
+		conformed := make([]interface{}, len(values))
+		for i, v := range values {
+			c, err := s.conformElem(key, v)
+			if err != nil {
+				return err
+			}
+			conformed[i] = c
+		}
+		values = conformed
+	}
+	if l, ok := d.value.(*rgaList); ok {
+		removed, err := l.splice(index, count, values, func() stamp { return s.clock.now(originator.as) })
+		if err != nil {
+			return err
+		}
+		s.changed(DataChange{Key: key, Index: index, OldValue: removed, NewValue: values, Originator: originator.contact, Kind: SpliceChange})
+		return nil
+	}
+	if c, ok := d.value.(crdt); ok {
+		return fmt.Errorf("%w: %v is a %v, not a list", ErrWrongType, key, c.kind())
+	}
+	array, ok := d.raw().([]interface{})
+	if !ok {
+		return fmt.Errorf("%w: %v is not an array", ErrWrongType, key)
+	}
+	if index < 0 || count < 0 || index+count > len(array) {
+		return fmt.Errorf("%w: %v %d+%d of %d", ErrOutOfRange, key, index, count, len(array))
+	}
+
+	removed := append([]interface{}{}, array[index:index+count]...)
+	avalue := make([]interface{}, 0, len(array)-count+len(values))
+	avalue = append(avalue, array[:index]...)
+	avalue = append(avalue, values...)
+	avalue = append(avalue, array[index+count:]...)
+	s.data[key] = d.with(nil, avalue)
+	s.changed(DataChange{Key: key, Index: index, OldValue: removed, NewValue: values, Originator: originator.contact, Kind: SpliceChange})
+	return nil
+}
+
+func (s *sharedData) DeleteMapKey(key string, mapKey string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.deleteMapKey(s.self(), key, mapKey)
+}
 
-	d, ok := s.data[key]
+func (s *sharedData) deleteMapKey(originator member, key string, mapKey string) error {
+	if err := s.checkOwner(originator, key); err != nil {
+		return err
+	}
+	d := s.data[key]
+	if m, ok := d.value.(*orMap); ok {
+		old := m.get(mapKey)
+		m.remove(mapKey)
+		s.changed(DataChange{Key: key, MapKey: mapKey, OldValue: old, Originator: originator.contact, Kind: DeleteMapChange})
+		return nil
+	}
+	dd, ok := d.value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%w: %v is not a map", ErrWrongType, key)
+	}
+	old, ok := dd[mapKey]
+	if !ok {
+		return nil
+	}
+	dd = copyMap(dd)
+	delete(dd, mapKey)
+	d.value = dd
+	s.data[key] = d
+	s.changed(DataChange{Key: key, MapKey: mapKey, OldValue: old, Originator: originator.contact, Kind: DeleteMapChange})
+	return nil
+}
+
+// Delete removes key altogether.
+func (s *sharedData) Delete(key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.deleteKey(s.self(), key)
+}
+
+func (s *sharedData) deleteKey(originator member, key string) error {
+	if err := s.checkOwner(originator, key); err != nil {
+		return err
+	}
+	s.remove(originator, key)
+	return nil
+}
+
+// remove deletes key without any checks, like load it is used for changes
+// that were already accepted.
+func (s *sharedData) remove(originator member, key string) {
+	old, ok := s.data[key]
 	if !ok {
 		return
 	}
-	d.avalue = append(d.avalue, value)
-	s.data[key] = data{nil, d.avalue, d.owner, d.visibility}
+	delete(s.data, key)
+	s.listeners.notify(DataChange{Key: key, OldValue: old.get(), Originator: originator.contact, Kind: DeleteChange, Version: old.version + 1, Visibility: old.visibility})
+}
+
+// Len returns the number of elements in the array or map key, or 0 if it
+// is neither.
+func (s *sharedData) Len(key string) int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	v := reflect.ValueOf(s.data[key].get())
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len()
+	}
+	return 0
 }
 
 func (s *sharedData) GetOwner(key string) string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	return s.data[key].owner
 }
 
 func (s *sharedData) SetMe(me string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.members.removeRole(s.me)
 	s.me = me
+	s.members.addRole(me)
+}
+
+// self is the local member, as whom local changes are made.
+func (s *sharedData) self() member {
+	return member{s.local, s.me}
 }
 
+// GetMe needs no lock, the role is chosen before the shared data is shared.
 func (s *sharedData) GetMe() string {
 	return s.me
 }
 
 func (s *sharedData) IsMe(other string) bool {
-	// TODO am I in this group?  is this a group?
-	return s.me == other
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.members.includes(other, s.me)
 }
 
-func (s *sharedData) OnDataChangeCB(cb func(string)) {
-	s.cb = cb
+func (s *sharedData) OnDataChangeCB(cb func(DataChange)) func() {
+	return s.listeners.add(cb)
 }
 
-func (s *sharedData) ChangeDataOwner(key string, owner string) {
-	// fmt.Printf("%v) @@@ ChangeDataOwner %v:%v \n", s.id, key, owner)
+func (s *sharedData) ChangeDataOwner(key string, owner string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.changeDataOwner(s.self(), key, owner)
+}
 
-	data, ok := s.data[key]
-	if !ok {
-		// fmt.Printf("%v) @@@ ChangeDataOwner Could not find key %v\n", s.id, key)
-		return
+func (s *sharedData) changeDataOwner(originator member, key string, owner string) error {
+	// fmt.Printf("%v) @@@ ChangeDataOwner %v:%v \n", s.id, key, owner)
+	if err := s.checkOwner(originator, key); err != nil {
+		return err
 	}
-	// if data.owner != s.me {
-	// 	panic(fmt.Sprintf("@@@ Tried to change owner of key(%v) from (%v) to (%v), I am %v", key, data.owner, owner, s.me))
-	// 	// return
-	// }
-	// fmt.Printf("%v) @@@ ChangeDataOwner owner for key(%v) changed from %v to %v\n", s.id, key, data.owner, owner)
+
+	data := s.data[key]
+	old := data.owner
 	data.owner = owner
 	s.data[key] = data
+	s.changed(DataChange{Key: key, OldValue: old, NewValue: owner, Originator: originator.contact, Kind: OwnerChange})
+	return nil
+}
+
+// DefineGroup names a set of roles that can be used as the owner or
+// visibility of keys.  Only the host can define groups and redefining a
+// group does not send hidden values to roles that were added to it, use
+// SetVisibility for that.
+func (s *sharedData) DefineGroup(group string, roles []string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.defineGroup(s.self(), group, roles)
+}
+
+func (s *sharedData) defineGroup(originator member, group string, roles []string) error {
+	if group == DefaultGroup {
+		return fmt.Errorf("the %v group can't be redefined", DefaultGroup)
+	}
+	if err := s.checkCreator(originator); err != nil {
+		return err
+	}
+	s.members.defineGroup(group, roles)
+	return nil
+}
+
+func (s *sharedData) GetGroup(group string) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.members.getGroup(group)
+}
+
+// SetVisibility changes who can see the value of key, the owner of key
+// can use this to reveal it to more members.
+func (s *sharedData) SetVisibility(key string, visibility string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.setVisibility(s.self(), key, visibility, s.data[key].raw(), s.data[key].version)
+}
+
+// setVisibility reveals value, at version, to us if we can see key now.
+func (s *sharedData) setVisibility(originator member, key string, visibility string, value interface{}, version uint64) error {
+	if err := s.checkOwner(originator, key); err != nil {
+		return err
+	}
+
+	d := s.data[key]
+	// Only a crdt, or a key we couldn't see, can be revealed as one
+	if c, ok := value.(crdt); ok && (d.value != nil || d.avalue != nil) && s.crdtOf(key) == nil {
+		return fmt.Errorf("%w: %v can't be given a %v", ErrWrongType, key, c.kind())
+	}
+	old := d.visibility
+	if s.members.canSee(visibility, s.me) && value != nil {
+		revealed := newData(ownCopy(value), d.owner, d.visibility)
+		d = d.with(revealed.value, revealed.avalue)
+		d.version = version
+	}
+	d.visibility = visibility
+	s.data[key] = d
+	s.changed(DataChange{Key: key, OldValue: old, NewValue: visibility, Originator: originator.contact, Kind: VisibilityChange})
+	return nil
+}
+
+func (s *sharedData) GetVisibility(key string) string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.data[key].visibility
 }
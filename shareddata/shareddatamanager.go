@@ -1,9 +1,13 @@
 package shareddata
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/hoyle1974/grapevine/client"
 	"github.com/hoyle1974/grapevine/common"
@@ -14,11 +18,26 @@ import (
 type SharedDataManager interface {
 	GetMe() common.Contact
 	Serve(s SharedData) SharedData
-	JoinShare(s SharedData)
+	JoinShare(s SharedData) (SharedData, error)
 	LeaveShare(s SharedData)
 	Invite(s SharedData, recipient common.Contact, as string) bool
+	SendInvite(s SharedData, recipient common.Contact, as string, options InviteOptions) (Invitation, error)
+	RevokeInvite(id InviteId) error
+	AcceptInvite(id InviteId) (SharedData, error)
+	DeclineInvite(id InviteId, reason string) error
+	IncomingInvites() []Invitation
+	OutgoingInvites() []Invitation
+	CreateJoinToken(s SharedData, as string, expiry time.Duration) (string, error)
+	JoinWithToken(token string) (SharedData, error)
 	OnSharedDataRequestHttp(writer http.ResponseWriter, req *http.Request)
 	OnSharedDataRequest(uri string, body []byte) ([]byte, int)
+	SetCodec(codec Codec)
+	GetCodec() Codec
+	SetStore(store SharedDataStore)
+	GetStore() SharedDataStore
+	RegisterSchema(schema *Schema)
+	Resume() ([]SharedData, error)
+	Close()
 }
 
 type sharedDataManager struct {
@@ -28,6 +47,13 @@ type sharedDataManager struct {
 	cb          ClientCallback
 	data        map[SharedDataId]SharedDataProxy
 	clientCache client.GrapevineClientCache
+	configLock  sync.RWMutex
+	codec       Codec
+	store       SharedDataStore
+	schemas     map[string]*Schema
+	incoming    map[InviteId]Invitation
+	outgoing    map[InviteId]Invitation
+	tokenKey    []byte
 }
 
 func NewSharedDataManager(ctx common.CallCtx, myself common.Myself, cb ClientCallback, clientCache client.GrapevineClientCache) SharedDataManager {
@@ -37,6 +63,10 @@ func NewSharedDataManager(ctx common.CallCtx, myself common.Myself, cb ClientCal
 		cb:          cb,
 		ctx:         ctx.NewCtx("SharedDataManager"),
 		data:        make(map[SharedDataId]SharedDataProxy),
+		codec:       DefaultCodec,
+		incoming:    make(map[InviteId]Invitation),
+		outgoing:    make(map[InviteId]Invitation),
+		tokenKey:    newTokenKey(),
 	}
 }
 
@@ -62,9 +92,15 @@ func (sdm *sharedDataManager) OnSharedDataRequest(uri string, body []byte) ([]by
 	log := sdm.ctx.NewCtx("OnSharedDataRequest")
 
 	var resp proto.Message = nil
+	var proxy SharedDataProxy
+	var originator member
+	var err error
+	// later is run once the lock is released, for anything that has to talk
+	// to other members.  Holding the lock across a request to a manager that
+	// might be waiting on us would deadlock the two.
+	var later []func()
 
 	sdm.lock.Lock()
-	defer sdm.lock.Unlock()
 
 	switch uri {
 	case "/shareddata/invite":
@@ -73,10 +109,21 @@ func (sdm *sharedDataManager) OnSharedDataRequest(uri string, body []byte) ([]by
 		// We were invite to this shared data, make sure the CB knows
 		creator := common.NewContactFromPB(req.Creator)
 
+		// We can't play by rules we don't know
+		schema, serr := sdm.schemaFor(req.Schema, req.SchemaVersion)
+		if serr != nil {
+			sdm.ctx.Warn().Err(serr).Msgf("Refusing invite to %v", req.SharedDataId)
+			resp = &pb.SharedDataInviteResponse{Accepted: false}
+			break
+		}
+
 		// If we accept then we will create the object
 		if sdm.cb.OnInvited(SharedDataId(req.SharedDataId), req.As, creator) {
 			sd := NewSharedData(creator, SharedDataId(req.SharedDataId))
 			sd.SetMe(req.As)
+			if schema != nil {
+				sd.SetSchema(schema)
+			}
 			proxy := NewSharedDataProxy(sd, sdm)
 			proxy.AddInvitee(sdm.GetMe(), req.As)
 			sdm.data[sd.GetId()] = proxy
@@ -86,65 +133,608 @@ func (sdm *sharedDataManager) OnSharedDataRequest(uri string, body []byte) ([]by
 		} else {
 			resp = &pb.SharedDataInviteResponse{Accepted: false}
 		}
-	case "/shareddata/sendstate":
-		req := &pb.SharedDataSendState{}
+	case "/shareddata/offer":
+		req := &pb.SharedDataOffer{}
 		proto.Unmarshal(body, req)
 
-		id := SharedDataId(req.SharedDataId)
-		sd := sdm.data[id]
+		invite := invitationFromPB(req, sdm.GetMe())
+		if invite.expired(time.Now()) {
+			err = fmt.Errorf("%w: %v", ErrInviteExpired, invite.Id)
+			break
+		}
+		if _, err = sdm.schemaFor(req.Schema, req.SchemaVersion); err != nil {
+			break
+		}
+		sdm.incoming[invite.Id] = invite
+
+		resp = &pb.SharedDataOfferResponse{}
+		go sdm.cb.OnInviteReceived(invite)
+	case "/shareddata/answer":
+		req := &pb.SharedDataAnswer{}
+		proto.Unmarshal(body, req)
+
+		contact := common.NewContactFromPB(req.Originator)
+		var invite Invitation
+		invite, err = sdm.takeOutgoing(InviteId(req.InviteId), contact)
+		if err != nil {
+			break
+		}
+		if !req.Accepted {
+			resp = &pb.SharedDataAnswerResponse{}
+			go sdm.cb.OnInviteDeclined(invite, req.Reason)
+			break
+		}
+
+		var state *pb.SharedDataSendState
+		var next string
+		proxy, state, next, err = sdm.admit(invite.SharedDataId, contact, invite.As)
+		if err != nil {
+			break
+		}
+		later = append(later, sdm.announce(proxy, contact, invite.As))
+
+		resp = &pb.SharedDataAnswerResponse{State: state, Next: next}
+		go sdm.cb.OnInviteAccepted(proxy, contact)
+	case "/shareddata/redeem":
+		req := &pb.SharedDataRedeem{}
+		proto.Unmarshal(body, req)
 
-		for key, value := range req.Data {
-			sd.Create(key, fromBytes(value.Value), value.Owner, value.Visbility)
+		contact := common.NewContactFromPB(req.Originator)
+		var claims *pb.SharedDataJoinToken
+		claims, err = sdm.redeem(req.Token, contact)
+		if err != nil {
+			break
+		}
+
+		var state *pb.SharedDataSendState
+		var next string
+		proxy, state, next, err = sdm.admit(SharedDataId(claims.SharedDataId), contact, claims.As)
+		if err != nil {
+			break
+		}
+		later = append(later, sdm.announce(proxy, contact, claims.As))
+
+		resp = &pb.SharedDataRedeemResponse{State: state, Next: next}
+		go sdm.cb.OnInviteAccepted(proxy, contact)
+	case "/shareddata/revoke":
+		req := &pb.SharedDataRevoke{}
+		proto.Unmarshal(body, req)
+
+		invite, ok := sdm.incoming[InviteId(req.InviteId)]
+		if !ok || invite.From.AccountId != common.NewContactFromPB(req.Originator).AccountId {
+			err = fmt.Errorf("%w: %v", ErrUnknownInvite, req.InviteId)
+			break
 		}
+		delete(sdm.incoming, invite.Id)
+
+		resp = &pb.SharedDataRevokeResponse{}
+	case "/shareddata/sendstate":
+		req := &pb.SharedDataSendState{}
+		proto.Unmarshal(body, req)
 
-		for key, value := range req.Listeners {
-			sd.AddInvitee(common.NewContactFromPB(value), key)
+		proxy, ok := sdm.data[SharedDataId(req.SharedDataId)]
+		if !ok {
+			err = fmt.Errorf("%w: %v", ErrUnknownSharedData, req.SharedDataId)
+			break
+		}
+		if err = sdm.applyState(proxy, req); err != nil {
+			break
 		}
+
 		resp = &pb.SharedDataSendStateResponse{}
 	case "/shareddata/create":
 		req := &pb.SharedDataCreate{}
 		proto.Unmarshal(body, req)
 
-		id := SharedDataId(req.SharedDataId)
-
-		sdm.data[id].GetOrigin().Create(req.Key, fromBytes(req.Value), req.Owner, req.Visibility)
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			value, err := decodeCRDT(req.Codec, req.Value)
+			if err != nil {
+				return err
+			}
+			return originOf(proxy).create(originator, req.Key, value, req.Owner, req.Visibility)
+		})
+		if err != nil {
+			break
+		}
 
 		resp = &pb.SharedDataCreateResponse{}
+	case "/shareddata/createarray":
+		req := &pb.SharedDataCreateArray{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			value, err := decode(req.Codec, req.Value)
+			if err != nil {
+				return err
+			}
+			avalue, ok := value.([]interface{})
+			if !ok && value != nil {
+				return fmt.Errorf("%w: %v is a %T, not an array", ErrWrongType, req.Key, value)
+			}
+			return originOf(proxy).createArray(originator, req.Key, avalue, req.Owner, req.Visibility)
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataCreateArrayResponse{}
+	case "/shareddata/createmap":
+		req := &pb.SharedDataCreateMap{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			value, err := decode(req.Codec, req.Value)
+			if err != nil {
+				return err
+			}
+			if value == nil {
+				// A map we aren't allowed to see
+				return originOf(proxy).create(originator, req.Key, nil, req.Owner, req.Visibility)
+			}
+			return originOf(proxy).createMap(originator, req.Key, value, req.Owner, req.Visibility)
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataCreateMapResponse{}
 	case "/shareddata/set":
 		req := &pb.SharedDataSet{}
 		proto.Unmarshal(body, req)
 
-		id := SharedDataId(req.SharedDataId)
-		sdm.data[id].GetOrigin().Set(req.Key, fromBytes(req.Value))
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			value, err := decode(req.Codec, req.Value)
+			if err != nil {
+				return err
+			}
+			return originOf(proxy).setPath(originator, req.Key, parseSteps(req.Path), value)
+		})
+		if err != nil {
+			break
+		}
 
 		resp = &pb.SharedDataSetResponse{}
+	case "/shareddata/compareandset":
+		req := &pb.SharedDataCompareAndSet{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		var value interface{}
+		if value, err = decode(req.Codec, req.Value); err != nil {
+			break
+		}
+		if req.Author == nil {
+			// We host the share and are asked to decide.  The originator is
+			// waiting for the change so it is sent first, the rest get it in
+			// the background.
+			var d *delivery
+			if d, err = proxy.decide(originator, req.Key, req.Version, value); err != nil {
+				break
+			}
+			theirs := d.take(originator.as)
+			later = append(later, func() {
+				go d.send()
+				if err := theirs.send(); err != nil {
+					log.Warn().Err(err).Msgf("%v doesn't have %v yet", originator.as, req.Key)
+				}
+			})
+		} else {
+			err = proxy.receive(originator, req.Seq, func() error {
+				return proxy.decided(originator, common.NewContactFromPB(req.Author), req.Key, req.Version, value)
+			})
+		}
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataCompareAndSetResponse{}
 	case "/shareddata/setmap":
 		req := &pb.SharedDataSetMap{}
 		proto.Unmarshal(body, req)
 
-		id := SharedDataId(req.SharedDataId)
-		sdm.data[id].GetOrigin().SetMap(req.Key, req.MapKey, fromBytes(req.Value))
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			value, err := decode(req.Codec, req.Value)
+			if err != nil {
+				return err
+			}
+			return originOf(proxy).setMap(originator, req.Key, req.MapKey, value)
+		})
+		if err != nil {
+			break
+		}
 
 		resp = &pb.SharedDataSetMapResponse{}
 	case "/shareddata/append":
 		req := &pb.SharedDataAppend{}
 		proto.Unmarshal(body, req)
 
-		id := SharedDataId(req.SharedDataId)
-		sdm.data[id].GetOrigin().Append(req.Key, fromBytes(req.Value))
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			value, err := decode(req.Codec, req.Value)
+			if err != nil {
+				return err
+			}
+			return originOf(proxy).appendPath(originator, req.Key, parseSteps(req.Path), value)
+		})
+		if err != nil {
+			break
+		}
 
 		resp = &pb.SharedDataAppendResponse{}
+	case "/shareddata/splice":
+		req := &pb.SharedDataSplice{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			value, err := decode(req.Codec, req.Values)
+			if err != nil {
+				return err
+			}
+			values, ok := value.([]interface{})
+			if !ok && value != nil {
+				return fmt.Errorf("%w: splicing a %T into %v", ErrWrongType, value, req.Key)
+			}
+			return originOf(proxy).splice(originator, req.Key, int(req.Index), int(req.Count), values)
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataSpliceResponse{}
+	case "/shareddata/deletemapkey":
+		req := &pb.SharedDataDeleteMapKey{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			return originOf(proxy).deleteMapKey(originator, req.Key, req.MapKey)
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataDeleteMapKeyResponse{}
+	case "/shareddata/delete":
+		req := &pb.SharedDataDelete{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			return originOf(proxy).deletePath(originator, req.Key, parseSteps(req.Path))
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataDeleteResponse{}
+	case "/shareddata/transaction":
+		req := &pb.SharedDataTransaction{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			ops := make([]txOp, len(req.Ops))
+			for i, op := range req.Ops {
+				value, err := decodeCRDT(op.Codec, op.Value)
+				if err != nil {
+					return err
+				}
+				ops[i] = txOp{op.Op, op.Key, op.MapKey, value, op.Owner, op.Visibility}
+			}
+			return originOf(proxy).transaction(originator, func(tx *transaction) error {
+				for _, op := range ops {
+					if err := tx.apply(op); err != nil {
+						return err
+					}
+				}
+				return nil
+			}, nil)
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataTransactionResponse{}
 	case "/shareddata/changeowner":
 		req := &pb.SharedDataChangeOwner{}
 		proto.Unmarshal(body, req)
 
-		id := SharedDataId(req.SharedDataId)
-		sdm.data[id].GetOrigin().ChangeDataOwner(req.Key, req.Owner)
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			return originOf(proxy).setPathOwner(originator, req.Key, parseSteps(req.Path), req.Owner)
+		})
+		if err != nil {
+			break
+		}
 
 		resp = &pb.SharedDataChangeOwnerResponse{}
+	case "/shareddata/join":
+		req := &pb.SharedDataJoin{}
+		proto.Unmarshal(body, req)
+
+		id := SharedDataId(req.SharedDataId)
+		proxy, ok := sdm.data[id]
+		if !ok {
+			log.Warn().Msgf("Join for unknown shared data %v", id)
+			break
+		}
+
+		contact := common.NewContactFromPB(req.Originator)
+		if req.Announce {
+			// Another member admitted someone, start sending them updates too
+			if _, ok := proxy.memberOf(common.NewContactFromPB(req.Announcer)); !ok {
+				err = fmt.Errorf("%w: %v", ErrNotMember, common.NewContactFromPB(req.Announcer))
+				break
+			}
+			// But they can't take the place of someone else
+			if current, ok := proxy.GetInvitees()[req.As]; ok && current.AccountId != contact.AccountId {
+				err = fmt.Errorf("%w: %v is already played by %v", ErrConflict, req.As, current)
+				break
+			}
+			proxy.AddInvitee(contact, req.As)
+			resp = &pb.SharedDataJoinResponse{Accepted: true}
+			break
+		}
+
+		// Only members can rejoin, though their address may have changed
+		current, ok := proxy.GetInvitees()[req.As]
+		if !ok || current.AccountId != contact.AccountId {
+			resp = &pb.SharedDataJoinResponse{Accepted: false}
+			break
+		}
+		proxy.AddInvitee(contact, req.As)
+		// They start numbering their changes again, even from the same address
+		proxy.resetMail(req.As)
+		later = append(later, sdm.announce(proxy, contact, req.As))
+
+		// They are only sent what they don't have, and their part of it at first
+		var state *pb.SharedDataSendState
+		var next string
+		var versions map[string]uint64
+		proxy.withLock(func() {
+			state, next, err = proxy.getStateSince(req.As, req.Versions, "", stateChunkSize)
+			versions = originOf(proxy).versions()
+		})
+		if err != nil {
+			log.Error().Err(err).Msgf("Could not send state to %v", req.As)
+			break
+		}
+		resp = &pb.SharedDataJoinResponse{Accepted: true, State: state, Versions: versions, Next: next}
+	case "/shareddata/getstate":
+		req := &pb.SharedDataGetState{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		var state *pb.SharedDataSendState
+		var next string
+		proxy.withLock(func() {
+			state, next, err = proxy.getStateSince(originator.as, req.Versions, req.After, stateChunkSize)
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataGetStateResponse{State: state, Next: next}
+	case "/shareddata/heartbeat":
+		req := &pb.SharedDataHeartbeat{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		proxy.withLock(func() {
+			proxy.renew(originator.as)
+		})
+
+		resp = &pb.SharedDataHeartbeatResponse{}
+	case "/shareddata/host":
+		req := &pb.SharedDataHost{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			return proxy.hostTaken(originator, req.Previous)
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataHostResponse{}
+	case "/shareddata/reassign":
+		req := &pb.SharedDataReassign{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			return proxy.reassigned(originator, req.Key, req.Previous, req.Owner)
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataReassignResponse{}
+	case "/shareddata/requestownership":
+		req := &pb.SharedDataRequestOwnership{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		if err = proxy.ownershipRequested(originator, req.Key); err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataRequestOwnershipResponse{}
+	case "/shareddata/setsuccessor":
+		req := &pb.SharedDataSetSuccessor{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			return originOf(proxy).setSuccessor(originator, req.Key, req.Successor)
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataSetSuccessorResponse{}
+	case "/shareddata/leave":
+		req := &pb.SharedDataLeave{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		if originator.as != req.As {
+			err = fmt.Errorf("%w: %v can't remove %v", ErrNotOwner, originator.as, req.As)
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			proxy.removeInvitee(req.As)
+			return nil
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataLeaveResponse{}
+	case "/shareddata/definegroup":
+		req := &pb.SharedDataDefineGroup{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			if err := originOf(proxy).defineGroup(originator, req.Group, req.Roles); err != nil {
+				return err
+			}
+			proxy.snapshot()
+			return nil
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataDefineGroupResponse{}
+	case "/shareddata/setvisibility":
+		req := &pb.SharedDataSetVisibility{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			value, err := decodeCRDT(req.Codec, req.Value)
+			if err != nil {
+				return err
+			}
+			if req.Path != "" {
+				return originOf(proxy).setPathVisibility(originator, req.Key, parseSteps(req.Path), req.Visibility, value)
+			}
+			return originOf(proxy).setVisibility(originator, req.Key, req.Visibility, value, req.Version)
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataSetVisibilityResponse{}
+	case "/shareddata/merge":
+		req := &pb.SharedDataMerge{}
+		proto.Unmarshal(body, req)
+
+		proxy, originator, err = sdm.lookup(req.SharedDataId, req.Originator)
+		if err != nil {
+			break
+		}
+		err = proxy.receive(originator, req.Seq, func() error {
+			value, err := decodeCRDT(req.Codec, req.Value)
+			if err != nil {
+				return err
+			}
+			other, ok := value.(crdt)
+			if !ok {
+				return fmt.Errorf("%w: merge of %v without a crdt", ErrWrongType, req.Key)
+			}
+			return originOf(proxy).merge(originator, req.Key, other)
+		})
+		if err != nil {
+			break
+		}
+
+		resp = &pb.SharedDataMergeResponse{}
 	default:
 		log.Error().Msgf("Unsupported shared data command: %s", uri)
 	}
+	sdm.lock.Unlock()
+
+	for _, f := range later {
+		f()
+	}
+
+	if err != nil {
+		log.Warn().Err(err).Msgf("Rejected %s", uri)
+		return nil, errorStatus(err)
+	}
 
 	if resp != nil {
 		body, err := proto.Marshal(resp)
@@ -158,10 +748,170 @@ func (sdm *sharedDataManager) OnSharedDataRequest(uri string, body []byte) ([]by
 	return nil, http.StatusServiceUnavailable
 }
 
+// lookup finds the shared data a request is for and the member that sent it.
+func (sdm *sharedDataManager) lookup(sharedDataId string, originator *pb.UserContact) (SharedDataProxy, member, error) {
+	proxy, ok := sdm.data[SharedDataId(sharedDataId)]
+	if !ok {
+		return nil, member{}, fmt.Errorf("%w: %v", ErrUnknownSharedData, sharedDataId)
+	}
+	m, ok := proxy.memberOf(common.NewContactFromPB(originator))
+	if !ok {
+		return nil, member{}, fmt.Errorf("%w: %v", ErrNotMember, common.NewContactFromPB(originator))
+	}
+	return proxy, m, nil
+}
+
+// errorStatus maps a rejected request to the status code sent back to the originator.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotOwner), errors.Is(err, ErrNotMember), errors.Is(err, ErrInvalidToken):
+		return http.StatusForbidden
+	case errors.Is(err, ErrUnknownKey), errors.Is(err, ErrUnknownSharedData), errors.Is(err, ErrUnknownInvite):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInviteExpired):
+		return http.StatusGone
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrIncompatibleSchema):
+		return http.StatusPreconditionFailed
+	}
+	return http.StatusBadRequest
+}
+
 func (sdm *sharedDataManager) GetMe() common.Contact {
 	return sdm.myself.GetMe()
 }
 
+// SetCodec chooses how the values we send are encoded, values we receive
+// are decoded with whichever codec they were sent with.
+func (sdm *sharedDataManager) SetCodec(codec Codec) {
+	sdm.configLock.Lock()
+	defer sdm.configLock.Unlock()
+	sdm.codec = codec
+}
+
+func (sdm *sharedDataManager) GetCodec() Codec {
+	sdm.configLock.RLock()
+	defer sdm.configLock.RUnlock()
+	return sdm.codec
+}
+
+// SetStore has every change to the shares we are part of written through to
+// store, so they can be resumed after a restart.
+func (sdm *sharedDataManager) SetStore(store SharedDataStore) {
+	sdm.configLock.Lock()
+	defer sdm.configLock.Unlock()
+	sdm.store = store
+}
+
+func (sdm *sharedDataManager) GetStore() SharedDataStore {
+	sdm.configLock.RLock()
+	defer sdm.configLock.RUnlock()
+	return sdm.store
+}
+
+// Resume reloads the shares kept in the store.  Each is brought up to date
+// by rejoining it through a member still serving it, and then our state is
+// sent to the others so they start sending us changes again.
+func (sdm *sharedDataManager) Resume() ([]SharedData, error) {
+	log := sdm.ctx.NewCtx("Resume")
+
+	store := sdm.GetStore()
+	if store == nil {
+		return nil, nil
+	}
+	ids, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var shares []SharedData
+	sdm.lock.Lock()
+	for _, id := range ids {
+		if _, ok := sdm.data[id]; ok {
+			continue
+		}
+		proxy, err := sdm.restore(store, id)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Could not resume %v", id)
+			continue
+		}
+		sdm.data[id] = proxy
+		shares = append(shares, proxy)
+	}
+	sdm.lock.Unlock()
+
+	// The other members are only asked once they can reach us in turn
+	for _, share := range shares {
+		sdm.resync(share.(SharedDataProxy))
+	}
+	return shares, nil
+}
+
+// resync catches proxy up with the other members after it was restored.
+// Each member sends us the changes we missed and is sent the ones we made
+// that it missed in return.
+func (sdm *sharedDataManager) resync(proxy SharedDataProxy) {
+	log := sdm.ctx.NewCtx("resync")
+
+	for as, contact := range proxy.GetInvitees() {
+		if as == proxy.GetMe() {
+			continue
+		}
+		var versions map[string]uint64
+		proxy.withLock(func() {
+			versions = originOf(proxy).versions()
+		})
+		req := pb.SharedDataJoin{
+			SharedDataId: string(proxy.GetId()),
+			Originator:   sdm.GetMe().ToPB(),
+			As:           proxy.GetMe(),
+			Versions:     versions,
+		}
+		resp := pb.SharedDataJoinResponse{}
+		err := sdm.clientCache.POST(contact.Address, "/shareddata/join", &req, &resp)
+		if err != nil || !resp.Accepted {
+			continue
+		}
+		if err := sdm.fetchState(proxy, contact, resp.State, resp.Next); err != nil {
+			log.Warn().Err(err).Msgf("Could not use the state of %v from %v", proxy.GetId(), as)
+			continue
+		}
+		if err := proxy.sendStateSince(contact, resp.Versions); err != nil {
+			log.Warn().Err(err).Msgf("%v didn't survive", as)
+		}
+	}
+}
+
+// fetchState applies state, the first part of the state of proxy sent by
+// contact, and then fetches and applies the rest of it.
+func (sdm *sharedDataManager) fetchState(proxy SharedDataProxy, contact common.Contact, state *pb.SharedDataSendState, next string) error {
+	for {
+		if err := sdm.applyState(proxy, state); err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+
+		var versions map[string]uint64
+		proxy.withLock(func() {
+			versions = originOf(proxy).versions()
+		})
+		req := pb.SharedDataGetState{
+			SharedDataId: string(proxy.GetId()),
+			Originator:   sdm.GetMe().ToPB(),
+			Versions:     versions,
+			After:        next,
+		}
+		resp := pb.SharedDataGetStateResponse{}
+		if err := sdm.clientCache.POST(contact.Address, "/shareddata/getstate", &req, &resp); err != nil {
+			return err
+		}
+		state, next = resp.State, resp.Next
+	}
+}
+
 func (sdm *sharedDataManager) Serve(s SharedData) SharedData {
 	// log := sdm.ctx.NewCtx("Serve")
 	sdm.lock.Lock()
@@ -169,6 +919,11 @@ func (sdm *sharedDataManager) Serve(s SharedData) SharedData {
 
 	proxy := NewSharedDataProxy(s, sdm)
 	proxy.AddInvitee(sdm.GetMe(), s.GetMe())
+	proxy.withLock(func() {
+		if origin := originOf(proxy); origin.host == "" {
+			origin.host = s.GetMe()
+		}
+	})
 
 	// Add this shared data to our system
 	sdm.data[s.GetId()] = proxy
@@ -176,23 +931,239 @@ func (sdm *sharedDataManager) Serve(s SharedData) SharedData {
 	return proxy
 }
 
-func (sdm *sharedDataManager) JoinShare(s SharedData) {
+// applyState loads a copy of a shared data, or the part of one we were
+// missing, as sent by SendStateTo, into proxy.
+func (sdm *sharedDataManager) applyState(proxy SharedDataProxy, state *pb.SharedDataSendState) error {
+	schema, err := sdm.schemaFor(state.Schema, state.SchemaVersion)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]interface{}, len(state.Data))
+	for key, value := range state.Data {
+		v, err := decodeCRDT(value.Codec, value.Value)
+		if err != nil {
+			return fmt.Errorf("%v: %w", key, err)
+		}
+		values[key] = v
+	}
+	entries := make([]HistoryEntry, 0, len(state.History))
+	for _, e := range state.History {
+		entry, err := decodeHistory(e)
+		if err != nil {
+			return fmt.Errorf("history of %v: %w", e.Key, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	proxy.withLock(func() {
+		origin := originOf(proxy)
+		if schema != nil && origin.schema == nil {
+			if err = origin.setSchema(schema); err != nil {
+				return
+			}
+		}
+		for group, roles := range state.Groups {
+			origin.members.defineGroup(group, roles.Roles)
+		}
+		if host, ok := state.Listeners[state.Host]; ok {
+			origin.host = state.Host
+			origin.hostAt = common.NewContactFromPB(host)
+		}
+		// Whoever admitted us may not be the creator
+		if state.Creator != nil {
+			origin.creator = common.NewContactFromPB(state.Creator)
+		}
+		if origin.contacts == nil {
+			origin.contacts = make(map[string]common.Contact, len(state.Listeners))
+		}
+		for as, contact := range state.Listeners {
+			origin.contacts[as] = common.NewContactFromPB(contact)
+		}
+
+		// Loading the state isn't a change, the sender's history says how it came to be
+		origin.history.loading(func() {
+			originator := member{contact: common.NewContactFromPB(state.Originator)}
+			for key, value := range state.Data {
+				// Our own changes to crdts are kept by merging
+				local := origin.crdtOf(key)
+				if remote, ok := values[key].(crdt); ok && local != nil {
+					if err = origin.mergeCRDT(local, remote); err != nil {
+						err = fmt.Errorf("%v: %w", key, err)
+						return
+					}
+					if d := origin.data[key]; d.version < value.Version {
+						d.version = value.Version
+						origin.data[key] = d
+					}
+					continue
+				}
+				origin.load(originator, key, decodeData(value, values[key]))
+			}
+			for _, key := range state.Deleted {
+				origin.remove(originator, key)
+			}
+		})
+		if state.Partial {
+			origin.history.merge(entries)
+		} else {
+			origin.history.replace(entries)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for key, value := range state.Listeners {
+		proxy.AddInvitee(common.NewContactFromPB(value), key)
+	}
+	return nil
+}
+
+// announce returns a func that tells every other member of proxy that member
+// has joined as the role as.  The members are the ones there now, the func is
+// meant to be called once the lock is released.
+func (sdm *sharedDataManager) announce(proxy SharedDataProxy, member common.Contact, as string) func() {
+	log := sdm.ctx.NewCtx("announce")
+
+	req := pb.SharedDataJoin{
+		SharedDataId: string(proxy.GetId()),
+		Originator:   member.ToPB(),
+		As:           as,
+		Announce:     true,
+		Announcer:    sdm.GetMe().ToPB(),
+	}
+	others := proxy.GetInvitees()
+	delete(others, as)
+	delete(others, proxy.GetMe())
+	return func() {
+		resp := pb.SharedDataJoinResponse{}
+		for key, value := range others {
+			err := sdm.clientCache.POST(value.Address, "/shareddata/join", &req, &resp)
+			if err != nil {
+				log.Warn().Err(err).Msgf("Could not tell %v about %v", key, as)
+			}
+		}
+	}
+}
+
+// JoinShare (re)joins an existing share as s.GetMe(), fetching the current
+// state from its creator.  s should be a fresh SharedData with the id and
+// creator of the share.
+func (sdm *sharedDataManager) JoinShare(s SharedData) (SharedData, error) {
 	// log := sdm.ctx.NewCtx("JoinShare")
 	sdm.lock.Lock()
 	defer sdm.lock.Unlock()
 
-	// @TODO
+	req := pb.SharedDataJoin{
+		SharedDataId: string(s.GetId()),
+		Originator:   sdm.GetMe().ToPB(),
+		As:           s.GetMe(),
+	}
+	if sd, ok := s.(*sharedData); ok {
+		sd.lock.RLock()
+		req.Versions = sd.versions()
+		sd.lock.RUnlock()
+	}
+	// Any member can let us back in, the creator may be long gone
+	var contact common.Contact
+	var resp pb.SharedDataJoinResponse
+	err := fmt.Errorf("%w: nobody to join %v through", ErrUnknownSharedData, s.GetId())
+	for _, contact = range joinContacts(s) {
+		resp = pb.SharedDataJoinResponse{}
+		if err = sdm.clientCache.POST(contact.Address, "/shareddata/join", &req, &resp); err != nil {
+			continue
+		}
+		if !resp.Accepted {
+			err = fmt.Errorf("join of %v as %v was refused", s.GetId(), s.GetMe())
+			continue
+		}
+		break
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := NewSharedDataProxy(s, sdm)
+	if err := sdm.fetchState(proxy, contact, resp.State, resp.Next); err != nil {
+		proxy.stop()
+		return nil, err
+	}
+	proxy.AddInvitee(sdm.GetMe(), s.GetMe())
 
+	sdm.data[s.GetId()] = proxy
+
+	return proxy, nil
 }
 
+// joinContacts returns who to ask to rejoin s, the host first, then the
+// creator and the other members we knew of.
+func joinContacts(s SharedData) []common.Contact {
+	sd, ok := s.(*sharedData)
+	if !ok {
+		return []common.Contact{s.GetCreator()}
+	}
+	sd.lock.RLock()
+	defer sd.lock.RUnlock()
+
+	roles := make([]string, 0, len(sd.contacts))
+	for as := range sd.contacts {
+		if as != sd.me && as != sd.host {
+			roles = append(roles, as)
+		}
+	}
+	sort.Strings(roles)
+
+	contacts := []common.Contact{sd.hostAt, sd.creator}
+	for _, as := range roles {
+		contacts = append(contacts, sd.contacts[as])
+	}
+	unique := contacts[:0]
+	for _, c := range contacts {
+		known := false
+		for _, u := range unique {
+			known = known || u.Address.Equal(c.Address)
+		}
+		if !known {
+			unique = append(unique, c)
+		}
+	}
+	return unique
+}
+
+// Close stops serving every share without telling the other members, as if
+// we had gone away.  Shares kept in the store can still be resumed.
+func (sdm *sharedDataManager) Close() {
+	sdm.lock.Lock()
+	defer sdm.lock.Unlock()
+
+	for id, proxy := range sdm.data {
+		proxy.stop()
+		delete(sdm.data, id)
+	}
+}
+
+// LeaveShare stops serving s locally and tells the other members we are gone.
 func (sdm *sharedDataManager) LeaveShare(s SharedData) {
-	// log := sdm.ctx.NewCtx("LeaveShare")
+	log := sdm.ctx.NewCtx("LeaveShare")
 
 	sdm.lock.Lock()
 	defer sdm.lock.Unlock()
 
-	// @TODO
+	proxy, ok := sdm.data[s.GetId()]
+	if !ok {
+		return
+	}
+	delete(sdm.data, s.GetId())
 
+	if err := proxy.leave(); err != nil {
+		log.Warn().Err(err).Msg("Not everyone could be told we left")
+	}
+	if store := sdm.GetStore(); store != nil {
+		if err := store.Remove(s.GetId()); err != nil {
+			log.Warn().Err(err).Msg("Could not forget the share")
+		}
+	}
 }
 
 func (sdm *sharedDataManager) Invite(s SharedData, recipient common.Contact, as string) bool {
@@ -201,12 +1172,22 @@ func (sdm *sharedDataManager) Invite(s SharedData, recipient common.Contact, as
 	sdm.lock.Lock()
 	defer sdm.lock.Unlock()
 
+	// We can only invite them to a share we serve
+	proxy, ok := sdm.data[s.GetId()]
+	if !ok {
+		log.Error().Msgf("%v: %v", ErrUnknownSharedData, s.GetId())
+		return false
+	}
+
 	// Tell the contact about this shared data, inviting them to it
 	invite := pb.SharedDataInvite{
 		SharedDataId: string(s.GetId()),
 		Creator:      s.GetCreator().ToPB(),
 		As:           as,
 	}
+	if schema := s.GetSchema(); schema != nil {
+		invite.Schema, invite.SchemaVersion = schema.Name, int32(schema.Version)
+	}
 
 	gresp := pb.SharedDataInviteResponse{}
 
@@ -217,12 +1198,16 @@ func (sdm *sharedDataManager) Invite(s SharedData, recipient common.Contact, as
 	}
 
 	if gresp.Accepted {
-		proxy := sdm.data[s.GetId()]
 		sdm.ctx.Info().Msgf("Add Invitee %v", recipient)
 		proxy.AddInvitee(recipient, as)
 
 		sdm.ctx.Info().Msgf("Send State %v", recipient)
-		proxy.SendStateTo(recipient)
+		if err := proxy.SendStateTo(recipient); err != nil {
+			log.Error().Err(err).Msgf("Could not send state to %v", recipient)
+			proxy.RemoveInvitee(as)
+			return false
+		}
+		sdm.announce(proxy, recipient, as)()
 
 		sdm.ctx.Info().Msgf("Invite Accepted by %v", recipient)
 		go sdm.cb.OnInviteAccepted(proxy, recipient)
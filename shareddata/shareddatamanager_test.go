@@ -1,18 +1,24 @@
 package shareddata
 
 import (
-	"encoding/gob"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"sort"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/hoyle1974/grapevine/client"
 	"github.com/hoyle1974/grapevine/common"
+	pb "github.com/hoyle1974/grapevine/proto"
 	"github.com/quic-go/quic-go"
 	"github.com/quic-go/quic-go/http3"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 var globalPorts = 8192
@@ -31,12 +37,34 @@ func newLocalListener(ctx common.CallCtx, sdm SharedDataManager) *http3.Server {
 	port := sdm.GetMe().Address.Port
 	mux := http.NewServeMux()
 	mux.HandleFunc("/shareddata/invite", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/offer", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/answer", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/revoke", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/redeem", sdm.OnSharedDataRequestHttp)
 	mux.HandleFunc("/shareddata/create", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/createarray", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/createmap", sdm.OnSharedDataRequestHttp)
 	mux.HandleFunc("/shareddata/changeowner", sdm.OnSharedDataRequestHttp)
 	mux.HandleFunc("/shareddata/set", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/compareandset", sdm.OnSharedDataRequestHttp)
 	mux.HandleFunc("/shareddata/setmap", sdm.OnSharedDataRequestHttp)
 	mux.HandleFunc("/shareddata/append", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/splice", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/deletemapkey", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/delete", sdm.OnSharedDataRequestHttp)
 	mux.HandleFunc("/shareddata/sendstate", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/join", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/getstate", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/leave", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/heartbeat", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/host", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/reassign", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/requestownership", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/setsuccessor", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/definegroup", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/setvisibility", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/merge", sdm.OnSharedDataRequestHttp)
+	mux.HandleFunc("/shareddata/transaction", sdm.OnSharedDataRequestHttp)
 
 	quicConf := &quic.Config{
 		MaxIdleTimeout: time.Minute * 10,
@@ -56,7 +84,7 @@ func newLocalListener(ctx common.CallCtx, sdm SharedDataManager) *http3.Server {
 		certFile := "/Users/jstrohm/code/grapevine/grapevine/cert.pem"
 		keyFile := "/Users/jstrohm/code/grapevine/grapevine/priv.key"
 		err := server.ListenAndServeTLS(certFile, keyFile)
-		if err != nil {
+		if err != nil && !errors.Is(err, quic.ErrServerClosed) && !errors.Is(err, http.ErrServerClosed) {
 			panic(err)
 		}
 	}()
@@ -80,7 +108,9 @@ func TestSharedDataManager(t *testing.T) {
 	cc := client.NewGrapevineClientCache()
 
 	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
 	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
 
 	server1 := newLocalListener(ctx, sdmUser1)
 	defer server1.CloseGracefully(0)
@@ -91,31 +121,107 @@ func TestSharedDataManager(t *testing.T) {
 	assert.Equal(t, user2.GetMe(), sdmUser2.GetMe(), "sdmUser2 doesn't match user2!")
 
 	sd1 := NewSharedData(user1.GetMe(), "test")
+	sd1.SetMe("user1")
 	sd1.Create("key", "bar", "user1", "")
-	sdmUser1.Serve(sd1)
+	proxy1 := sdmUser1.Serve(sd1)
 
 	ok := sdmUser1.Invite(sd1, user2.GetMe(), "user2")
 	assert.Equal(t, ok, true, "Invite failed")
 
-	assert.Equal(t, sd1.GetId(), user2Cb.sharedDataId, "Shared Data Id did not match")
-	assert.Equal(t, "user2", user2Cb.me, "Not the role I expected")
+	id, me := user2Cb.Invited()
+	assert.Equal(t, sd1.GetId(), id, "Shared Data Id did not match")
+	assert.Equal(t, "user2", me, "Not the role I expected")
 
 	// time.Sleep(time.Second * 1)
 
 	assert.Equal(t, "bar", sd1.Get("key"), "String didn't match")
 
 	// Try sending some data back and forth
-	sd2 := user2Cb.sharedData
+	sd2 := user2Cb.SharedData()
 
 	assert.Equal(t, "bar", sd2.Get("key"), "String didn't match")
 
-	sd2.Set("key", "foo")
+	err := sd2.Set("key", "foo")
+	assert.ErrorIs(t, err, ErrNotOwner, "user2 shouldn't be able to write user1's key")
+	assert.Equal(t, "bar", sd1.Get("key"), "String didn't match")
+
+	err = proxy1.ChangeDataOwner("key", "user2")
+	assert.Nil(t, err)
+	assert.Equal(t, "user2", sd2.GetOwner("key"), "Owner didn't change")
+
+	err = sd2.Set("key", "foo")
+	assert.Nil(t, err)
 
 	// time.Sleep(time.Second * 1)
 
 	assert.Equal(t, "foo", sd1.Get("key"), "String didn't match")
 }
 
+func TestOwnership(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestOwnership")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	osd1.Create("board", ".........", "player1", "default")
+	osd1.Create("chat", "", DefaultGroup, "default")
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+
+	// A cheating peer skips its local checks and posts straight to user1
+	value, _ := toBytes("OOO......")
+	req := &pb.SharedDataSet{
+		SharedDataId: "test",
+		Originator:   user2.GetMe().ToPB(),
+		Key:          "board",
+		Value:        value,
+	}
+	body, _ := proto.Marshal(req)
+	_, status := sdmUser1.OnSharedDataRequest("/shareddata/set", body)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, ".........", sd1.Get("board"), "Rejected write was applied")
+
+	// Strangers can't write even to the default group
+	value, _ = toBytes("hello")
+	req = &pb.SharedDataSet{
+		SharedDataId: "test",
+		Originator:   common.NewTestMyself("User3", nextPort()).GetMe().ToPB(),
+		Key:          "chat",
+		Value:        value,
+	}
+	body, _ = proto.Marshal(req)
+	_, status = sdmUser1.OnSharedDataRequest("/shareddata/set", body)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	// Members of the default group can
+	assert.Nil(t, user2Cb.SharedData().Set("chat", "hello"))
+	assert.Equal(t, "hello", sd1.Get("chat"))
+
+	// Only the owner can give a key away
+	assert.ErrorIs(t, user2Cb.SharedData().ChangeDataOwner("board", "player2"), ErrNotOwner)
+	assert.Nil(t, sd1.ChangeDataOwner("board", "player2"))
+	assert.Nil(t, user2Cb.SharedData().Set("board", "....O...."))
+	assert.Equal(t, "....O....", sd1.Get("board"))
+	assert.ErrorIs(t, sd1.Set("board", "X...O...."), ErrNotOwner)
+}
+
 type TestUserData struct {
 	Name string
 }
@@ -130,6 +236,7 @@ func TestArrays(t *testing.T) {
 	user1 := common.NewTestMyself("User1", nextPort())
 	user1Cb := NewTestClientCb("user1")
 	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
 	server1 := newLocalListener(ctx, sdmUser1)
 	defer server1.CloseGracefully(0)
 
@@ -137,14 +244,15 @@ func TestArrays(t *testing.T) {
 	user2 := common.NewTestMyself("User2", nextPort())
 	user2Cb := NewTestClientCb("user2")
 	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
 	server2 := newLocalListener(ctx, sdmUser2)
 	defer server2.CloseGracefully(0)
 
 	// User 1 create the game
 	osd1 := NewSharedData(user1.GetMe(), "test")
 
-	gob.Register(TestUserData{})
-	gob.Register([]TestUserData{})
+	RegisterType(TestUserData{})
+	RegisterType([]TestUserData{})
 
 	osd1.SetMe("player1")
 	osd1.Create("chat", []string{}, "default", "default")
@@ -157,10 +265,11 @@ func TestArrays(t *testing.T) {
 	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
 	assert.Equal(t, ok, true, "Invite failed")
 
-	assert.Equal(t, sd1.GetId(), user2Cb.sharedDataId, "Shared Data Id did not match")
-	assert.Equal(t, "player2", user2Cb.me, "Not the role I expected")
+	id, me := user2Cb.Invited()
+	assert.Equal(t, sd1.GetId(), id, "Shared Data Id did not match")
+	assert.Equal(t, "player2", me, "Not the role I expected")
 
-	sd2 := user2Cb.sharedData
+	sd2 := user2Cb.SharedData()
 
 	sd1.Append("chat", "chat from user1")
 	sd2.Append("chat", "chat from user2")
@@ -188,12 +297,17 @@ func TestArrays(t *testing.T) {
 	assert.Equal(t, u1.Name, "user1")
 	assert.Equal(t, u2.Name, "user2")
 
+	// An array that is set can still be appended to
+	assert.Nil(t, sd1.Set("chat", []interface{}{"cleared"}))
+	assert.Nil(t, sd2.Append("chat", "chat after clearing"))
+	assert.Equal(t, []interface{}{"cleared", "chat after clearing"}, sd1.Get("chat"))
+	assert.Equal(t, sd1.Get("chat"), sd2.Get("chat"))
 }
 
 func TestMap(t *testing.T) {
 	ctx := common.NewCallCtxWithApp("TestMap")
 
-	gob.Register(map[string]interface{}{})
+	RegisterType(map[string]interface{}{})
 
 	// Shared
 	cc := client.NewGrapevineClientCache()
@@ -202,6 +316,7 @@ func TestMap(t *testing.T) {
 	user1 := common.NewTestMyself("User1", nextPort())
 	user1Cb := NewTestClientCb("user1")
 	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
 	server1 := newLocalListener(ctx, sdmUser1)
 	defer server1.CloseGracefully(0)
 
@@ -209,14 +324,15 @@ func TestMap(t *testing.T) {
 	user2 := common.NewTestMyself("User2", nextPort())
 	user2Cb := NewTestClientCb("user2")
 	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
 	server2 := newLocalListener(ctx, sdmUser2)
 	defer server2.CloseGracefully(0)
 
 	// User 1 create the game
 	osd1 := NewSharedData(user1.GetMe(), "test")
 
-	gob.Register(TestUserData{})
-	gob.Register([]TestUserData{})
+	RegisterType(TestUserData{})
+	RegisterType([]TestUserData{})
 
 	osd1.SetMe("player1")
 	osd1.CreateMap("map", map[string]string{}, "default", "default")
@@ -227,10 +343,11 @@ func TestMap(t *testing.T) {
 	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
 	assert.Equal(t, ok, true, "Invite failed")
 
-	assert.Equal(t, sd1.GetId(), user2Cb.sharedDataId, "Shared Data Id did not match")
-	assert.Equal(t, "player2", user2Cb.me, "Not the role I expected")
+	id, me := user2Cb.Invited()
+	assert.Equal(t, sd1.GetId(), id, "Shared Data Id did not match")
+	assert.Equal(t, "player2", me, "Not the role I expected")
 
-	sd2 := user2Cb.sharedData
+	sd2 := user2Cb.SharedData()
 
 	sd1.SetMap("map", "k1", "v1")
 	sd2.SetMap("map", "k2", "v2")
@@ -243,3 +360,2029 @@ func TestMap(t *testing.T) {
 	assert.Equal(t, c["k2"], "v2", "k2 is wrong")
 
 }
+
+func TestContainers(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestContainers")
+
+	RegisterType(TestUserData{})
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	// Created before user2 joins, so sent with the state
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("board", ".........", DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.CreateArray("users", []interface{}{TestUserData{"user1"}}, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.CreateMap("scores", map[string]int{"player1": 1}, DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	assert.Equal(t, ".........", sd2.Get("board"))
+	assert.Equal(t, []interface{}{TestUserData{"user1"}}, sd2.Get("users"))
+	assert.Equal(t, map[string]interface{}{"player1": 1}, sd2.Get("scores"))
+
+	// Created after, so sent as changes
+	assert.Nil(t, sd1.Create("turn", "player1", DefaultGroup, DefaultGroup))
+	assert.Nil(t, sd1.CreateArray("chat", []interface{}{"hello"}, DefaultGroup, DefaultGroup))
+	assert.Nil(t, sd1.CreateMap("names", map[string]string{"player1": "user1"}, DefaultGroup, DefaultGroup))
+	assert.Nil(t, sd1.CreateArray("secrets", []interface{}{"hidden"}, "player1", "player1"))
+	assert.Nil(t, sd1.CreateMap("notes", map[string]string{"a": "hidden"}, "player1", "player1"))
+
+	assert.Equal(t, "player1", sd2.Get("turn"))
+	assert.Equal(t, []interface{}{"hello"}, sd2.Get("chat"))
+	assert.Equal(t, map[string]interface{}{"player1": "user1"}, sd2.Get("names"))
+	assert.Nil(t, sd2.Get("secrets"))
+	assert.Nil(t, sd2.Get("notes"))
+	assert.Equal(t, "player1", sd2.GetOwner("notes"))
+
+	// Both kinds of container can still be changed from either side
+	assert.Nil(t, sd2.Append("users", TestUserData{"user2"}))
+	assert.Nil(t, sd2.Append("chat", "hi"))
+	assert.Nil(t, sd2.SetMap("scores", "player2", 2))
+	assert.Nil(t, sd2.SetMap("names", "player2", "user2"))
+	assert.Equal(t, []interface{}{TestUserData{"user1"}, TestUserData{"user2"}}, sd1.Get("users"))
+	assert.Equal(t, []interface{}{"hello", "hi"}, sd1.Get("chat"))
+	assert.Equal(t, map[string]interface{}{"player1": 1, "player2": 2}, sd1.Get("scores"))
+	assert.Equal(t, map[string]interface{}{"player1": "user1", "player2": "user2"}, sd1.Get("names"))
+}
+
+func TestCollections(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestCollections")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.CreateArray("chat", []interface{}{"a", "b", "c"}, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.CreateMap("inventory", map[string]int{"sword": 1, "shield": 1}, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.CreateCRDT("lobby", RGAList, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.Create("board", ".........", "player1", DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	assert.Nil(t, sd1.InsertAt("chat", 1, "x"))
+	assert.Nil(t, sd2.RemoveAt("chat", 0))
+	assert.Nil(t, sd1.Splice("chat", 1, 1, "y", "z"))
+	assert.Equal(t, []interface{}{"x", "y", "z", "c"}, sd1.Get("chat"))
+	assert.Equal(t, []interface{}{"x", "y", "z", "c"}, sd2.Get("chat"))
+	assert.Equal(t, 4, sd2.Len("chat"))
+	assert.ErrorIs(t, sd2.RemoveAt("chat", 4), ErrOutOfRange)
+	assert.ErrorIs(t, sd2.InsertAt("inventory", 0, "x"), ErrWrongType)
+
+	assert.Nil(t, sd2.DeleteMapKey("inventory", "sword"))
+	assert.Equal(t, map[string]interface{}{"shield": 1}, sd1.Get("inventory"))
+	assert.Equal(t, 1, sd1.Len("inventory"))
+	assert.Equal(t, 0, sd1.Len("board"))
+
+	assert.Nil(t, sd1.Append("lobby", "player1"))
+	assert.Nil(t, sd2.Append("lobby", "player2"))
+	assert.Nil(t, sd2.InsertAt("lobby", 0, "host"))
+	assert.Nil(t, sd1.RemoveAt("lobby", 1))
+	assert.Equal(t, []interface{}{"host", "player2"}, sd1.Get("lobby"))
+	assert.Equal(t, []interface{}{"host", "player2"}, sd2.Get("lobby"))
+
+	assert.ErrorIs(t, sd2.Delete("board"), ErrNotOwner)
+	assert.Nil(t, sd1.Delete("board"))
+	assert.Nil(t, sd2.Get("board"))
+	assert.Equal(t, "", sd2.GetOwner("board"))
+}
+
+func TestLeaveShare(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestLeaveShare")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	osd1.Create("board", ".........", "player2", "default")
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	assert.Contains(t, sd1.(SharedDataProxy).GetInvitees(), "player2")
+
+	sdmUser2.LeaveShare(user2Cb.SharedData())
+
+	assert.NotContains(t, sd1.(SharedDataProxy).GetInvitees(), "player2")
+	assert.Equal(t, "default", sd1.GetOwner("board"), "Keys owned by the leaver should be reassigned")
+}
+
+func TestJoinShare(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestJoinShare")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	osd1.Create("board", ".........", "default", "default")
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+
+	// User 2 comes back on a new address and rejoins the share
+	user2b := common.NewTestMyself("User2", nextPort())
+	user2bCb := NewTestClientCb("user2b")
+	sdmUser2b := NewSharedDataManager(ctx.NewCtx("sdm2b"), user2b, user2bCb, cc)
+	defer sdmUser2b.Close()
+	server2b := newLocalListener(ctx, sdmUser2b)
+	defer server2b.CloseGracefully(0)
+
+	osd2 := NewSharedData(user1.GetMe(), "test")
+	osd2.SetMe("player2")
+	sd2, err := sdmUser2b.JoinShare(osd2)
+	assert.Nil(t, err)
+	assert.Equal(t, ".........", sd2.Get("board"), "State wasn't transferred")
+
+	sd1.Set("board", "X........")
+	assert.Equal(t, "X........", sd2.Get("board"), "Update didn't reach the rejoined member")
+
+	// Nor can anyone be invited to a share we don't serve
+	assert.False(t, sdmUser2b.Invite(NewSharedData(user2b.GetMe(), "elsewhere"), user1.GetMe(), "player1"))
+
+	// Someone who was never invited can't join
+	user3 := common.NewTestMyself("User3", nextPort())
+	sdmUser3 := NewSharedDataManager(ctx.NewCtx("sdm3"), user3, NewTestClientCb("user3"), cc)
+	defer sdmUser3.Close()
+	osd3 := NewSharedData(user1.GetMe(), "test")
+	osd3.SetMe("player3")
+	_, err = sdmUser3.JoinShare(osd3)
+	assert.NotNil(t, err)
+
+	// Or announce themselves, and members can't hand out a role someone else plays
+	announce := func(announcer common.Contact, as string) int {
+		body, _ := proto.Marshal(&pb.SharedDataJoin{
+			SharedDataId: "test",
+			Originator:   user3.GetMe().ToPB(),
+			As:           as,
+			Announce:     true,
+			Announcer:    announcer.ToPB(),
+		})
+		_, status := sdmUser1.OnSharedDataRequest("/shareddata/join", body)
+		return status
+	}
+	assert.Equal(t, http.StatusForbidden, announce(user3.GetMe(), "player3"))
+	assert.Equal(t, http.StatusConflict, announce(user2b.GetMe(), "player2"))
+	assert.True(t, user2b.GetMe().Address.Equal(sd1.(SharedDataProxy).GetInvitees()["player2"].Address))
+}
+
+func TestDataChangeCB(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestDataChangeCB")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	osd1.Create("board", ".........", "default", "default")
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	changes1 := make(chan DataChange, 10)
+	sd1.OnDataChangeCB(func(change DataChange) { changes1 <- change })
+	changes2 := make(chan DataChange, 10)
+	unsubscribe := sd2.OnDataChangeCB(func(change DataChange) { changes2 <- change })
+
+	sd2.Set("board", "X........")
+
+	for _, changes := range []chan DataChange{changes1, changes2} {
+		select {
+		case change := <-changes:
+			assert.Equal(t, "board", change.Key)
+			assert.Equal(t, SetChange, change.Kind)
+			assert.Equal(t, ".........", change.OldValue)
+			assert.Equal(t, "X........", change.NewValue)
+			assert.Equal(t, user2.GetMe().AccountId, change.Originator.AccountId)
+		case <-time.After(time.Second):
+			t.Fatal("No change received")
+		}
+	}
+
+	unsubscribe()
+	sd1.ChangeDataOwner("board", "player2")
+
+	select {
+	case change := <-changes1:
+		assert.Equal(t, OwnerChange, change.Kind)
+		assert.Equal(t, "default", change.OldValue)
+		assert.Equal(t, "player2", change.NewValue)
+	case <-time.After(time.Second):
+		t.Fatal("No change received")
+	}
+
+	select {
+	case change := <-changes2:
+		t.Fatalf("Unsubscribed callback received %v", change)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+func TestVisibility(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestVisibility")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.DefineGroup("hand1", []string{"player1"}))
+	osd1.Create("hand1", "AK", "player1", "hand1")
+	osd1.Create("table", "", DefaultGroup, DefaultGroup)
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	assert.Nil(t, sd2.Get("hand1"), "Hidden value was sent")
+	assert.Equal(t, "player1", sd2.GetOwner("hand1"), "Hidden key should still be known")
+	assert.Equal(t, []string{"player1"}, sd2.GetGroup("hand1"), "Groups weren't sent")
+
+	assert.Nil(t, sd1.Set("hand1", "AKQ"))
+	assert.Nil(t, sd1.Set("table", "2"))
+	assert.Nil(t, sd2.Get("hand1"), "Hidden value was sent")
+	assert.Equal(t, "2", sd2.Get("table"))
+
+	assert.ErrorIs(t, sd2.DefineGroup("hand1", []string{"player1", "player2"}), ErrNotOwner)
+
+	// Reveal the hand to everyone
+	assert.Nil(t, sd1.SetVisibility("hand1", DefaultGroup))
+	assert.Equal(t, "AKQ", sd2.Get("hand1"), "Revealed value wasn't sent")
+}
+
+func TestDeliveryErrors(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestDeliveryErrors")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("board", ".........", DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+
+	// Values the codec can't encode are rejected before anything changes
+	assert.NotNil(t, sd1.Set("board", make(chan int)))
+	assert.Equal(t, ".........", sd1.Get("board"))
+	assert.ErrorIs(t, sd1.CreateMap("map", "not a map", DefaultGroup, DefaultGroup), ErrWrongType)
+
+	// Garbage values are refused by the receiver
+	req := &pb.SharedDataSet{
+		SharedDataId: "test",
+		Originator:   user1.GetMe().ToPB(),
+		Key:          "board",
+		Value:        []byte("garbage"),
+	}
+	body, _ := proto.Marshal(req)
+	_, status := sdmUser2.OnSharedDataRequest("/shareddata/set", body)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	// Changes are still made when a member can't be reached, but it is reported
+	user3 := common.NewTestMyself("User3", nextPort())
+	sd1.(SharedDataProxy).AddInvitee(user3.GetMe(), "player3")
+	err := sd1.Set("board", "X........")
+	var derr *DeliveryError
+	if assert.ErrorAs(t, err, &derr) {
+		assert.Contains(t, derr.Failed, "player3")
+		assert.NotContains(t, derr.Failed, "player2")
+	}
+	assert.Equal(t, "X........", sd1.Get("board"))
+	assert.Equal(t, "X........", user2Cb.SharedData().Get("board"))
+	sd1.(SharedDataProxy).RemoveInvitee("player3")
+
+	// A change the host refuses is undone by fetching its copy back
+	assert.Nil(t, sd1.Create("turn", "player1", "player1", DefaultGroup))
+	sd2 := user2Cb.SharedData()
+	body, _ = proto.Marshal(&pb.SharedDataChangeOwner{SharedDataId: "test", Originator: user1.GetMe().ToPB(), Key: "turn", Owner: "player2"})
+	_, status = sdmUser2.OnSharedDataRequest("/shareddata/changeowner", body)
+	assert.Equal(t, http.StatusOK, status)
+	err = sd2.Set("turn", "player2")
+	if assert.ErrorAs(t, err, &derr) {
+		assert.Contains(t, derr.Failed, "player1")
+	}
+	assert.Eventually(t, func() bool {
+		return sd2.Get("turn") == "player1" && sd2.GetOwner("turn") == "player1"
+	}, time.Second*5, time.Millisecond*10, "The refused change was kept")
+	assert.Equal(t, "player1", sd1.Get("turn"))
+}
+
+func TestOrderedDelivery(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestOrderedDelivery")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("board", ".........", "player1", DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1).(SharedDataProxy)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	post := func(seq uint64, msg proto.Message, uri string) int {
+		msg.ProtoReflect().Set(msg.ProtoReflect().Descriptor().Fields().ByName("seq"), protoreflect.ValueOfUint64(seq))
+		body, _ := proto.Marshal(msg)
+		_, status := sdmUser2.OnSharedDataRequest(uri, body)
+		return status
+	}
+	value, _ := toBytes("X........")
+	set := &pb.SharedDataSet{SharedDataId: "test", Originator: user1.GetMe().ToPB(), Key: "board", Value: value}
+	changeOwner := &pb.SharedDataChangeOwner{SharedDataId: "test", Originator: user1.GetMe().ToPB(), Key: "board", Owner: "player2"}
+
+	// The owner change is made after the set but arrives first, it has to wait
+	assert.Equal(t, http.StatusOK, post(2, changeOwner, "/shareddata/changeowner"))
+	assert.Equal(t, "player1", sd2.GetOwner("board"), "Change was applied before the one it follows")
+	assert.Equal(t, http.StatusOK, post(1, set, "/shareddata/set"))
+	assert.Equal(t, "X........", sd2.Get("board"))
+	assert.Equal(t, "player2", sd2.GetOwner("board"))
+
+	// Retries of changes we already have are ignored
+	value, _ = toBytes("O........")
+	set.Value = value
+	assert.Equal(t, http.StatusOK, post(1, set, "/shareddata/set"))
+	assert.Equal(t, "X........", sd2.Get("board"))
+
+	// Being sent the state again while changes are on their way loses none of them
+	value, _ = toBytes("hi")
+	create := &pb.SharedDataCreate{SharedDataId: "test", Originator: user1.GetMe().ToPB(), Key: "chat", Value: value, Owner: "player1"}
+	value, _ = toBytes("bye")
+	set = &pb.SharedDataSet{SharedDataId: "test", Originator: user1.GetMe().ToPB(), Key: "chat", Value: value}
+	assert.Equal(t, http.StatusOK, post(4, set, "/shareddata/set"))
+	var state *pb.SharedDataSendState
+	sd1.withLock(func() {
+		state, _, _ = sd1.getStateSince("player2", originOf(sd1).versions(), "", 0)
+	})
+	body, _ := proto.Marshal(state)
+	_, status := sdmUser2.OnSharedDataRequest("/shareddata/sendstate", body)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, http.StatusOK, post(3, create, "/shareddata/create"))
+	assert.Equal(t, "bye", sd2.Get("chat"))
+}
+
+func TestRetryDelivery(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestRetryDelivery")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.CreateArray("moves", []interface{}{}, "player1", DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	// player2 drops off for a while
+	server2.Close()
+	assert.NotNil(t, sd1.Append("moves", "a1"))
+	assert.ErrorIs(t, sd1.Append("moves", "b2"), ErrQueued)
+
+	server2 = newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	assert.Eventually(t, func() bool {
+		return len(sd2.Get("moves").([]interface{})) == 2
+	}, time.Second*30, time.Millisecond*100, "Changes were never resent")
+	assert.Equal(t, []interface{}{"a1", "b2"}, sd2.Get("moves"))
+	assert.Nil(t, sd1.Append("moves", "c3"))
+	assert.Equal(t, []interface{}{"a1", "b2", "c3"}, sd2.Get("moves"))
+}
+
+func TestCRDT(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestCRDT")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.CreateCRDT("score", PNCounter, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.CreateCRDT("moves", GCounter, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.CreateCRDT("topic", LWWRegister, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.CreateCRDT("chat", RGAList, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.CreateCRDT("tags", ORSet, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.CreateCRDT("names", ORMap, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.Increment("score", 10))
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+	assert.Equal(t, int64(10), sd2.Get("score"))
+
+	// Both players change everything at once
+	var wg sync.WaitGroup
+	for i, sd := range []SharedData{sd1, sd2} {
+		wg.Add(1)
+		go func(i int, sd SharedData) {
+			defer wg.Done()
+			assert.Nil(t, sd.Increment("score", -3))
+			assert.Nil(t, sd.Increment("moves", 1))
+			assert.Nil(t, sd.Set("topic", fmt.Sprintf("topic %d", i)))
+			assert.Nil(t, sd.Append("chat", fmt.Sprintf("hello from %d", i)))
+			assert.Nil(t, sd.AddToSet("tags", "shared"))
+			assert.Nil(t, sd.AddToSet("tags", fmt.Sprintf("tag %d", i)))
+			assert.Nil(t, sd.SetMap("names", fmt.Sprintf("player%d", i+1), fmt.Sprintf("name %d", i)))
+		}(i, sd)
+	}
+	wg.Wait()
+
+	for _, key := range []string{"score", "moves", "topic", "chat", "tags", "names"} {
+		assert.Equal(t, sd1.Get(key), sd2.Get(key), "%v didn't converge", key)
+	}
+	assert.Equal(t, int64(4), sd1.Get("score"))
+	assert.Equal(t, int64(2), sd1.Get("moves"))
+	assert.Len(t, sd1.Get("chat"), 2)
+	assert.Equal(t, []interface{}{"shared", "tag 0", "tag 1"}, sd1.Get("tags"))
+	assert.Equal(t, map[string]interface{}{"player1": "name 0", "player2": "name 1"}, sd1.Get("names"))
+
+	assert.Nil(t, sd2.RemoveFromSet("tags", "shared"))
+	assert.Equal(t, []interface{}{"tag 0", "tag 1"}, sd1.Get("tags"))
+
+	assert.ErrorIs(t, sd1.Increment("moves", -1), ErrWrongType)
+	assert.ErrorIs(t, sd1.Append("topic", "x"), ErrWrongType)
+	assert.ErrorIs(t, sd1.CreateCRDT("bad", CRDTKind(99), DefaultGroup, DefaultGroup), ErrWrongType)
+
+	// A plain key can't be made a crdt by setting one
+	assert.Nil(t, sd1.Create("plain", "x", DefaultGroup, DefaultGroup))
+	b, err := encode(JSONCodec{}, &gCounter{Counts: map[string]uint64{}})
+	assert.Nil(t, err)
+	body, _ := proto.Marshal(&pb.SharedDataSet{SharedDataId: "test", Originator: user1.GetMe().ToPB(), Key: "plain", Value: b, Codec: JSONCodecId})
+	_, status := sdmUser2.OnSharedDataRequest("/shareddata/set", body)
+	assert.Equal(t, http.StatusBadRequest, status)
+	b, err = JSONCodec{}.Encode(&gCounter{Counts: map[string]uint64{}})
+	assert.Nil(t, err)
+	body, _ = proto.Marshal(&pb.SharedDataSet{SharedDataId: "test", Originator: user1.GetMe().ToPB(), Key: "plain", Value: b, Codec: JSONCodecId})
+	_, status = sdmUser2.OnSharedDataRequest("/shareddata/set", body)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "x", sd2.Get("plain"))
+
+	// Nor is a malformed crdt merged
+	b, err = JSONCodec{}.Encode(&pnCounter{})
+	assert.Nil(t, err)
+	body, _ = proto.Marshal(&pb.SharedDataMerge{SharedDataId: "test", Originator: user1.GetMe().ToPB(), Key: "score", Value: b, Codec: JSONCodecId})
+	_, status = sdmUser2.OnSharedDataRequest("/shareddata/merge", body)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Nil(t, sd2.Increment("score", 1))
+	assert.Equal(t, int64(5), sd1.Get("score"))
+}
+
+func TestCodecs(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestCodecs")
+
+	RegisterType(TestUserData{})
+
+	for _, codec := range []Codec{JSONCodec{}, CBORCodec{}, GobCodec{}} {
+		b, err := encode(codec, TestUserData{"user1"})
+		assert.Nil(t, err)
+		value, err := decode(codec.Id(), b)
+		assert.Nil(t, err)
+		assert.Equal(t, TestUserData{"user1"}, value, codec.Id())
+	}
+	b, err := encode(ProtobufCodec{}, "hello")
+	assert.Nil(t, err)
+	value, err := decode(ProtobufCodecId, b)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", value)
+	_, err = encode(ProtobufCodec{}, TestUserData{"user1"})
+	assert.ErrorIs(t, err, ErrWrongType)
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	sdmUser2.SetCodec(CBORCodec{})
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("user", TestUserData{"nobody"}, DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+	assert.Equal(t, TestUserData{"nobody"}, sd2.Get("user"))
+
+	// Members using different codecs still understand each other
+	assert.Nil(t, sd1.Set("user", TestUserData{"user1"}))
+	assert.Equal(t, TestUserData{"user1"}, sd2.Get("user"))
+	assert.Nil(t, sd2.Set("user", TestUserData{"user2"}))
+	assert.Equal(t, TestUserData{"user2"}, sd1.Get("user"))
+
+	// Whichever codec sends a crdt its values keep their types
+	for _, codec := range []Codec{JSONCodec{}, CBORCodec{}, ProtobufCodec{}, GobCodec{}} {
+		sdmUser1.SetCodec(codec)
+		key := "count " + codec.Id()
+		assert.Nil(t, sd1.CreateCRDT(key, LWWRegister, DefaultGroup, DefaultGroup))
+		assert.Nil(t, sd1.Set(key, 7))
+		assert.Equal(t, 7, sd2.Get(key), codec.Id())
+		assert.Nil(t, sd2.Set(key, int64(8)))
+		assert.Equal(t, int64(8), sd1.Get(key), codec.Id())
+	}
+	sdmUser1.SetCodec(DefaultCodec)
+
+	// Values sent before codecs could be chosen are gob
+	legacy, _ := toBytes(TestUserData{"legacy"})
+	req := &pb.SharedDataSet{
+		SharedDataId: "test",
+		Originator:   user1.GetMe().ToPB(),
+		Key:          "user",
+		Value:        legacy,
+	}
+	body, _ := proto.Marshal(req)
+	_, status := sdmUser2.OnSharedDataRequest("/shareddata/set", body)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, TestUserData{"legacy"}, sd2.Get("user"))
+
+	// Codecs we don't know are refused
+	req.Codec = "xml"
+	body, _ = proto.Marshal(req)
+	_, status = sdmUser2.OnSharedDataRequest("/shareddata/set", body)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestTransaction(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestTransaction")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("board", ".........", "player1", DefaultGroup))
+	assert.Nil(t, osd1.Create("state", "player1", "player1", DefaultGroup))
+	assert.Nil(t, osd1.CreateCRDT("moves", GCounter, DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	// Observers never see the board without the turn that goes with it
+	changes := make(chan DataChange, 10)
+	states := make(chan interface{}, 10)
+	sd2.OnDataChangeCB(func(change DataChange) {
+		changes <- change
+		states <- sd2.Get("state")
+	})
+
+	err := sd1.Transaction(func(tx Tx) error {
+		if tx.Get("state") != "player1" {
+			return errors.New("not our turn")
+		}
+		tx.Set("board", "X........")
+		tx.Set("state", "player2")
+		tx.Create("secret", "hidden", "player1", "player1")
+		tx.ChangeDataOwner("board", "player2")
+		return tx.ChangeDataOwner("state", "player2")
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, sd1.Increment("moves", 1))
+
+	for _, kind := range []ChangeKind{SetChange, SetChange, CreateChange, OwnerChange, OwnerChange} {
+		select {
+		case change := <-changes:
+			assert.Equal(t, kind, change.Kind)
+			assert.Equal(t, "player2", <-states)
+		case <-time.After(time.Second):
+			t.Fatal("No change received")
+		}
+	}
+	assert.Equal(t, "X........", sd2.Get("board"))
+	assert.Equal(t, "player2", sd2.GetOwner("state"))
+	assert.Nil(t, sd2.Get("secret"))
+	assert.Equal(t, "player1", sd2.GetOwner("secret"))
+
+	// Nothing is kept when a change isn't allowed or the function fails
+	err = sd2.Transaction(func(tx Tx) error {
+		tx.Set("board", "XO.......")
+		tx.Increment("moves", 1)
+		return tx.Set("secret", "mine")
+	})
+	assert.ErrorIs(t, err, ErrNotOwner)
+	err = sd2.Transaction(func(tx Tx) error {
+		tx.Set("board", "XO.......")
+		return errors.New("changed my mind")
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "X........", sd2.Get("board"))
+	assert.Equal(t, "X........", sd1.Get("board"))
+	assert.Equal(t, int64(1), sd2.Get("moves"))
+
+	// Crdts are merged as part of the transaction
+	err = sd2.Transaction(func(tx Tx) error {
+		tx.Set("board", "XO.......")
+		tx.Increment("moves", 1)
+		return tx.Set("state", "player1")
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "XO.......", sd1.Get("board"))
+	assert.Equal(t, int64(2), sd1.Get("moves"))
+
+	// The same holds for transactions from other members
+	value, _ := encode(DefaultCodec, "XOX......")
+	req := &pb.SharedDataTransaction{
+		SharedDataId: "test",
+		Originator:   user2.GetMe().ToPB(),
+		Ops: []*pb.SharedDataOp{
+			{Op: "set", Key: "board", Value: value, Codec: JSONCodecId},
+			{Op: "set", Key: "secret", Value: value, Codec: JSONCodecId},
+		},
+	}
+	body, _ := proto.Marshal(req)
+	_, status := sdmUser1.OnSharedDataRequest("/shareddata/transaction", body)
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "XO.......", sd1.Get("board"))
+}
+
+func TestCompareAndSet(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestCompareAndSet")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("state", "player1", DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.Set("state", "player1"))
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	// Versions are sent with the state
+	value, version := sd2.GetVersioned("state")
+	assert.Equal(t, "player1", value)
+	assert.Equal(t, uint64(2), version)
+
+	assert.Nil(t, sd1.CompareAndSet("state", version, "player2"))
+	value, version = sd2.GetVersioned("state")
+	assert.Equal(t, "player2", value)
+	assert.Equal(t, uint64(3), version)
+
+	assert.ErrorIs(t, sd2.CompareAndSet("state", 2, "player1"), ErrConflict)
+	assert.ErrorIs(t, sd2.SetIf("state", "player1", func(current interface{}) bool { return current == "player1" }), ErrConflict)
+	assert.Nil(t, sd2.SetIf("state", "player1", func(current interface{}) bool { return current == "player2" }))
+	assert.Equal(t, "player1", sd1.Get("state"))
+
+	// When both try at once the host lets only one of them through
+	_, version = sd1.GetVersioned("state")
+	errs := make(chan error, 2)
+	go func() { errs <- sd1.CompareAndSet("state", version, "host") }()
+	go func() { errs <- sd2.CompareAndSet("state", version, "member") }()
+	err1, err2 := <-errs, <-errs
+	assert.True(t, (err1 == nil) != (err2 == nil), "%v %v", err1, err2)
+	for _, err := range []error{err1, err2} {
+		if err != nil {
+			assert.ErrorIs(t, err, ErrConflict)
+		}
+	}
+	value, version = sd1.GetVersioned("state")
+	value2, version2 := sd2.GetVersioned("state")
+	assert.Equal(t, value, value2)
+	assert.Equal(t, version, version2)
+
+	// Two hosts deciding for each other at once don't wait on each other
+	osd2 := NewSharedData(user2.GetMe(), "other")
+	osd2.SetMe("player2")
+	assert.Nil(t, osd2.Create("state", "player2", DefaultGroup, DefaultGroup))
+	sdmUser2.Serve(osd2)
+	ok = sdmUser2.Invite(osd2, user1.GetMe(), "player1")
+	assert.Equal(t, true, ok, "Invite failed")
+	other1 := user1Cb.SharedData()
+	for i := 0; i < 10; i++ {
+		_, version = sd2.GetVersioned("state")
+		_, otherVersion := other1.GetVersioned("state")
+		go func(i int) { errs <- sd2.CompareAndSet("state", version, i) }(i)
+		go func(i int) { errs <- other1.CompareAndSet("state", otherVersion, i) }(i)
+		for j := 0; j < 2; j++ {
+			select {
+			case err := <-errs:
+				assert.Nil(t, err)
+			case <-time.After(10 * time.Second):
+				t.Fatal("compare and set is stuck")
+			}
+		}
+	}
+
+	// Other members check the version too and report conflicts
+	b, _ := encode(DefaultCodec, "finished")
+	req := &pb.SharedDataSet{SharedDataId: "test", Originator: user1.GetMe().ToPB(), Key: "state", Value: b, Codec: JSONCodecId}
+	body, _ := proto.Marshal(req)
+	_, status := sdmUser2.OnSharedDataRequest("/shareddata/set", body)
+	assert.Equal(t, http.StatusOK, status)
+
+	_, version = sd1.GetVersioned("state")
+	err := sd1.CompareAndSet("state", version, "player2")
+	assert.ErrorIs(t, err, ErrConflict)
+	var derr *DeliveryError
+	assert.ErrorAs(t, err, &derr)
+	assert.Equal(t, "finished", sd2.Get("state"))
+}
+
+func TestResume(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestResume")
+	dir := t.TempDir()
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	store, err := NewFileStore(dir)
+	assert.Nil(t, err)
+	sdmUser1.SetStore(store)
+	server1 := newLocalListener(ctx, sdmUser1)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("board", ".........", "player1", DefaultGroup))
+	assert.Nil(t, osd1.CreateArray("chat", nil, DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	assert.Nil(t, sd1.Set("board", "X........"))
+	assert.Nil(t, sd1.Append("chat", "hi"))
+	assert.Nil(t, sd1.DefineGroup("players", []string{"player1", "player2"}))
+
+	// User1 goes away and misses a change
+	server1.Close()
+	var derr *DeliveryError
+	assert.ErrorAs(t, sd2.Append("chat", "are you there?"), &derr)
+
+	// It comes back somewhere else with only what it stored
+	user1 = common.NewTestMyself("User1", nextPort())
+	sdmUser1 = NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	store, err = NewFileStore(dir)
+	assert.Nil(t, err)
+	sdmUser1.SetStore(store)
+	server1 = newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	shares, err := sdmUser1.Resume()
+	assert.Nil(t, err)
+	if !assert.Equal(t, 1, len(shares)) {
+		return
+	}
+	sd1 = shares[0]
+	assert.Equal(t, SharedDataId("test"), sd1.GetId())
+	assert.Equal(t, "player1", sd1.GetMe())
+	assert.Equal(t, "X........", sd1.Get("board"))
+	assert.Equal(t, []interface{}{"hi", "are you there?"}, sd1.Get("chat"))
+	assert.Equal(t, []string{"player1", "player2"}, sd1.GetGroup("players"))
+
+	// And carries on where it left off
+	assert.Nil(t, sd1.Set("board", "X...O...."))
+	assert.Equal(t, "X...O....", sd2.Get("board"))
+	assert.Nil(t, sd2.Append("chat", "welcome back"))
+	assert.Equal(t, []interface{}{"hi", "are you there?", "welcome back"}, sd1.Get("chat"))
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	assert.Nil(t, err)
+
+	_, _, err = store.Load("test/1")
+	assert.ErrorIs(t, err, ErrUnknownSharedData)
+
+	assert.Nil(t, store.Snapshot("test/1", []byte("snapshot")))
+	assert.Nil(t, store.Append("test/1", []byte("one")))
+	assert.Nil(t, store.Append("test/1", []byte("two")))
+
+	snapshot, records, err := store.Load("test/1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("snapshot"), snapshot)
+	assert.Equal(t, [][]byte{[]byte("one"), []byte("two")}, records)
+
+	ids, err := store.List()
+	assert.Nil(t, err)
+	assert.Equal(t, []SharedDataId{"test/1"}, ids)
+
+	// A length written just before a crash is left out like its record
+	file, err := os.OpenFile(store.(*fileStore).path("test/1", logExt), os.O_APPEND|os.O_WRONLY, 0)
+	assert.Nil(t, err)
+	_, err = file.Write(binary.AppendUvarint(nil, 1<<62))
+	assert.Nil(t, err)
+	assert.Nil(t, file.Close())
+	_, records, err = store.Load("test/1")
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("one"), []byte("two")}, records)
+
+	// A new snapshot replaces the records
+	assert.Nil(t, store.Snapshot("test/1", []byte("snapshot2")))
+	snapshot, records, err = store.Load("test/1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("snapshot2"), snapshot)
+	assert.Empty(t, records)
+
+	assert.Nil(t, store.Remove("test/1"))
+	ids, err = store.List()
+	assert.Nil(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestHistory(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestHistory")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("board", ".........", "player1", DefaultGroup))
+	assert.Nil(t, osd1.Create("secret", "hidden", "player1", "player1"))
+	assert.Nil(t, osd1.CreateArray("chat", nil, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.CreateCRDT("score", PNCounter, DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	assert.Nil(t, sd1.Set("board", "X........"))
+	since := time.Now()
+	assert.Nil(t, sd1.Set("board", "X...O...."))
+	assert.Nil(t, sd1.Set("secret", "still hidden"))
+	assert.Nil(t, sd2.Append("chat", "hi"))
+	assert.Nil(t, sd2.Increment("score", 3))
+
+	// Everyone sees the same history, even of what happened before they joined
+	board := sd1.History("board", time.Time{})
+	if assert.Equal(t, 3, len(board)) {
+		assert.Equal(t, CreateChange, board[0].Kind)
+		assert.Equal(t, "X........", board[2].OldValue)
+		assert.Equal(t, "X...O....", board[2].NewValue)
+		assert.Equal(t, user1.GetMe().AccountId, board[2].Originator.AccountId)
+	}
+	for i, entry := range sd2.History("board", time.Time{}) {
+		assert.Equal(t, board[i].Id, entry.Id)
+		assert.Equal(t, board[i].NewValue, entry.NewValue)
+	}
+	assert.Equal(t, 1, len(sd1.History("board", since)))
+	assert.Equal(t, 4, len(sd1.History("", since)))
+
+	// but only the values they can see, and changes to them
+	assert.Equal(t, 3, len(sd2.History("", since)))
+	secret := sd2.History("secret", time.Time{})
+	if assert.Equal(t, 1, len(secret)) {
+		assert.Nil(t, secret[0].NewValue)
+	}
+
+	// Only the owner can take a move back
+	assert.ErrorIs(t, sd2.Undo(board[2].Id), ErrNotOwner)
+	assert.Nil(t, sd1.Undo(board[2].Id))
+	assert.Equal(t, "X........", sd1.Get("board"))
+	assert.Equal(t, "X........", sd2.Get("board"))
+
+	chat := sd2.History("chat", since)
+	if assert.Equal(t, 1, len(chat)) {
+		assert.Nil(t, sd2.Undo(chat[0].Id))
+	}
+	assert.Equal(t, []interface{}{}, sd1.Get("chat"))
+	assert.ErrorIs(t, sd2.Undo(chat[0].Id), ErrConflict)
+
+	score := sd1.History("score", since)
+	if assert.Equal(t, 1, len(score)) {
+		assert.Nil(t, sd1.Undo(score[0].Id))
+	}
+	assert.Equal(t, int64(0), sd2.Get("score"))
+
+	assert.ErrorIs(t, sd1.Undo("nothing@1"), ErrUnknownOp)
+}
+
+func TestDeltaState(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestDeltaState")
+
+	defer func(size int) { stateChunkSize = size }(stateChunkSize)
+	stateChunkSize = 64
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	for i := 0; i < 20; i++ {
+		assert.Nil(t, osd1.Create(fmt.Sprintf("key%02d", i), i, DefaultGroup, DefaultGroup))
+	}
+	assert.Nil(t, osd1.Create("gone", "soon", DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	// The state is sent in parts
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, i, sd2.Get(fmt.Sprintf("key%02d", i)))
+	}
+	assert.Equal(t, "soon", sd2.Get("gone"))
+
+	var versions map[string]uint64
+	sd2.(SharedDataProxy).withLock(func() {
+		versions = originOf(sd2.(SharedDataProxy)).versions()
+	})
+	assert.Nil(t, sd1.Set("key05", "changed"))
+	assert.Nil(t, sd1.Delete("gone"))
+
+	join := func(versions map[string]uint64) *pb.SharedDataJoinResponse {
+		req := &pb.SharedDataJoin{SharedDataId: "test", Originator: user2.GetMe().ToPB(), As: "player2", Versions: versions}
+		body, _ := proto.Marshal(req)
+		out, status := sdmUser1.OnSharedDataRequest("/shareddata/join", body)
+		assert.Equal(t, http.StatusOK, status)
+		resp := &pb.SharedDataJoinResponse{}
+		assert.Nil(t, proto.Unmarshal(out, resp))
+		return resp
+	}
+
+	// Rejoining with what we had only sends what changed since
+	resp := join(versions)
+	assert.True(t, resp.Accepted)
+	assert.True(t, resp.State.Partial)
+	assert.Equal(t, []string{"key05"}, keysOf(resp.State.Data))
+	assert.Equal(t, []string{"gone"}, resp.State.Deleted)
+	assert.Equal(t, "", resp.Next)
+
+	// Joining with nothing sends everything, a part at a time
+	resp = join(nil)
+	keys := keysOf(resp.State.Data)
+	parts := 1
+	for next := resp.Next; next != ""; parts++ {
+		req := &pb.SharedDataGetState{SharedDataId: "test", Originator: user2.GetMe().ToPB(), After: next}
+		body, _ := proto.Marshal(req)
+		out, status := sdmUser1.OnSharedDataRequest("/shareddata/getstate", body)
+		assert.Equal(t, http.StatusOK, status)
+		page := &pb.SharedDataGetStateResponse{}
+		assert.Nil(t, proto.Unmarshal(out, page))
+		keys = append(keys, keysOf(page.State.Data)...)
+		next = page.Next
+	}
+	assert.Greater(t, parts, 1)
+	assert.Equal(t, 20, len(keys))
+	assert.NotContains(t, keys, "gone")
+}
+
+func keysOf(data map[string]*pb.SharedDataData) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestLeases(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestLeases")
+
+	defer func(interval, lease time.Duration) {
+		heartbeatInterval, leaseDuration = interval, lease
+	}(heartbeatInterval, leaseDuration)
+	heartbeatInterval, leaseDuration = 50*time.Millisecond, time.Second
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+
+	user3 := common.NewTestMyself("User3", nextPort())
+	user3Cb := NewTestClientCb("user3")
+	sdmUser3 := NewSharedDataManager(ctx.NewCtx("sdm3"), user3, user3Cb, cc)
+	defer sdmUser3.Close()
+	server3 := newLocalListener(ctx, sdmUser3)
+	defer server3.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("turn", "player2", "player2", DefaultGroup))
+	assert.Nil(t, osd1.Create("score", 0, "player2", DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	ok := sdmUser1.Invite(osd1, user2.GetMe(), "player2")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd2 := user2Cb.SharedData()
+	ok = sdmUser1.Invite(osd1, user3.GetMe(), "player3")
+	assert.Equal(t, true, ok, "Invite failed")
+	sd3 := user3Cb.SharedData()
+
+	assert.Nil(t, sd2.SetSuccessor("turn", "player3"))
+	assert.Equal(t, "player3", sd1.GetSuccessor("turn"))
+	assert.ErrorIs(t, sd1.SetSuccessor("turn", "player1"), ErrNotOwner)
+
+	// Heartbeats keep the leases going
+	time.Sleep(2 * leaseDuration)
+	assert.Equal(t, "player2", sd1.GetOwner("turn"))
+	assert.Equal(t, "player2", sd3.GetOwner("score"))
+
+	// Nobody can take a key whose owner's lease is live, or give it to another
+	for owner, status := range map[string]int{"player3": http.StatusConflict, "player1": http.StatusForbidden} {
+		body, _ := proto.Marshal(&pb.SharedDataReassign{
+			SharedDataId: "test",
+			Originator:   user3.GetMe().ToPB(),
+			Key:          "score",
+			Previous:     "player2",
+			Owner:        owner,
+		})
+		_, got := sdmUser1.OnSharedDataRequest("/shareddata/reassign", body)
+		assert.Equal(t, status, got)
+		assert.Equal(t, "player2", sd1.GetOwner("score"))
+	}
+
+	// Until player2 disappears, then its keys go to the successor, or player1 who sorts first
+	server2.Close()
+	sdmUser2.Close()
+	assert.Eventually(t, func() bool {
+		return sd1.GetOwner("turn") == "player3" && sd3.GetOwner("turn") == "player3" &&
+			sd1.GetOwner("score") == "player1" && sd3.GetOwner("score") == "player1"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Keys can be asked for
+	requests := make(chan DataChange, 1)
+	sd1.OnDataChangeCB(func(change DataChange) {
+		if change.Kind == OwnershipRequest {
+			requests <- change
+		}
+	})
+	assert.Nil(t, sd3.RequestOwnership("score"))
+	select {
+	case request := <-requests:
+		assert.Equal(t, "score", request.Key)
+		assert.Equal(t, "player3", request.NewValue)
+		err := sd1.GrantOwnership(request.Key, request.NewValue.(string))
+		var derr *DeliveryError
+		if err != nil {
+			assert.ErrorAs(t, err, &derr)
+		}
+		assert.Equal(t, "player3", sd3.GetOwner("score"))
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Ownership was never requested")
+	}
+
+	assert.ErrorIs(t, sd3.GrantOwnership("score", "nobody"), ErrNotMember)
+	assert.ErrorIs(t, sd1.RequestOwnership("missing"), ErrUnknownKey)
+}
+
+func TestHostMigration(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestHostMigration")
+
+	defer func(interval, lease time.Duration) {
+		heartbeatInterval, leaseDuration = interval, lease
+	}(heartbeatInterval, leaseDuration)
+	heartbeatInterval, leaseDuration = 50*time.Millisecond, time.Second
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	user3 := common.NewTestMyself("User3", nextPort())
+	user3Cb := NewTestClientCb("user3")
+	sdmUser3 := NewSharedDataManager(ctx.NewCtx("sdm3"), user3, user3Cb, cc)
+	defer sdmUser3.Close()
+	server3 := newLocalListener(ctx, sdmUser3)
+	defer server3.CloseGracefully(0)
+
+	user4 := common.NewTestMyself("User4", nextPort())
+	user4Cb := NewTestClientCb("user4")
+	sdmUser4 := NewSharedDataManager(ctx.NewCtx("sdm4"), user4, user4Cb, cc)
+	defer sdmUser4.Close()
+	server4 := newLocalListener(ctx, sdmUser4)
+	defer server4.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("board", ".........", DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+	assert.Equal(t, "player1", sd1.GetHost())
+
+	assert.True(t, sdmUser1.Invite(osd1, user2.GetMe(), "player2"), "Invite failed")
+	sd2 := user2Cb.SharedData()
+	assert.True(t, sdmUser1.Invite(osd1, user3.GetMe(), "player3"), "Invite failed")
+	sd3 := user3Cb.SharedData()
+	assert.Equal(t, "player1", sd3.GetHost())
+	assert.ErrorIs(t, sd2.DefineGroup("team", []string{"player2", "player3"}), ErrNotOwner)
+
+	// Nobody can take over from a host that is still there
+	body, _ := proto.Marshal(&pb.SharedDataHost{
+		SharedDataId: "test",
+		Originator:   user3.GetMe().ToPB(),
+		Previous:     "player1",
+	})
+	_, status := sdmUser2.OnSharedDataRequest("/shareddata/host", body)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "player1", sd2.GetHost())
+
+	// When the host leaves the first of the others takes over
+	sdmUser1.LeaveShare(sd1)
+	assert.Eventually(t, func() bool {
+		return sd2.GetHost() == "player2" && sd3.GetHost() == "player2" &&
+			user2Cb.Host() == "player2" && user3Cb.Host() == "player2"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, user1.GetMe().AccountId, sd3.GetCreator().AccountId)
+
+	// And can do what the host does
+	assert.Nil(t, sd2.DefineGroup("team", []string{"player2", "player3"}))
+	assert.Equal(t, []string{"player2", "player3"}, sd3.GetGroup("team"))
+	assert.True(t, sdmUser2.Invite(sd2, user4.GetMe(), "player4"), "Invite failed")
+	sd4 := user4Cb.SharedData()
+	assert.Equal(t, "player2", sd4.GetHost())
+	assert.Equal(t, ".........", sd4.Get("board"))
+	assert.Nil(t, sd4.Set("board", "x........"))
+	assert.Equal(t, "x........", sd3.Get("board"))
+
+	// Members can rejoin though the creator has gone
+	sdmUser3.Close()
+	user3b := common.NewTestMyself("User3", nextPort())
+	sdmUser3b := NewSharedDataManager(ctx.NewCtx("sdm3b"), user3b, NewTestClientCb("user3b"), cc)
+	defer sdmUser3b.Close()
+	server3b := newLocalListener(ctx, sdmUser3b)
+	defer server3b.CloseGracefully(0)
+	sd3, err := sdmUser3b.JoinShare(sd3.(SharedDataProxy).GetOrigin())
+	if assert.Nil(t, err) {
+		assert.Nil(t, sd4.Set("board", "xo......."))
+		assert.Equal(t, "xo.......", sd3.Get("board"))
+	}
+}
+
+func TestInvitations(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestInvitations")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	user3 := common.NewTestMyself("User3", nextPort())
+	user3Cb := NewTestClientCb("user3")
+	sdmUser3 := NewSharedDataManager(ctx.NewCtx("sdm3"), user3, user3Cb, cc)
+	defer sdmUser3.Close()
+	server3 := newLocalListener(ctx, sdmUser3)
+	defer server3.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("board", ".........", DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	// The invitation waits for an answer
+	invite, err := sdmUser1.SendInvite(sd1, user2.GetMe(), "player2", InviteOptions{Message: "Fancy a game?", Metadata: map[string]string{"mode": "ranked"}})
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool { return len(user2Cb.Invites()) == 1 }, time.Second, 10*time.Millisecond)
+	incoming := sdmUser2.IncomingInvites()
+	assert.Equal(t, 1, len(incoming))
+	assert.Equal(t, invite.Id, incoming[0].Id)
+	assert.Equal(t, "Fancy a game?", incoming[0].Message)
+	assert.Equal(t, "ranked", incoming[0].Metadata["mode"])
+	assert.Equal(t, []Invitation{invite}, sdmUser1.OutgoingInvites())
+	assert.NotContains(t, sd1.(SharedDataProxy).GetInvitees(), "player2")
+
+	sd2, err := sdmUser2.AcceptInvite(invite.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, ".........", sd2.Get("board"))
+	assert.Nil(t, sd1.Set("board", "x........"))
+	assert.Equal(t, "x........", sd2.Get("board"))
+	assert.Empty(t, sdmUser1.OutgoingInvites())
+	assert.Empty(t, sdmUser2.IncomingInvites())
+	_, err = sdmUser2.AcceptInvite(invite.Id)
+	assert.ErrorIs(t, err, ErrUnknownInvite)
+
+	// Or is declined, which can be tried again should the sender be away
+	invite, err = sdmUser1.SendInvite(sd1, user3.GetMe(), "player3", InviteOptions{})
+	assert.Nil(t, err)
+	server1.Close()
+	err = sdmUser3.DeclineInvite(invite.Id, "busy")
+	assert.NotNil(t, err)
+	assert.NotErrorIs(t, err, ErrUnknownInvite)
+	assert.Equal(t, 1, len(sdmUser3.IncomingInvites()))
+	server1 = newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+	assert.Eventually(t, func() bool { return sdmUser3.DeclineInvite(invite.Id, "busy") == nil }, time.Second*30, time.Millisecond*100)
+	assert.Empty(t, sdmUser3.IncomingInvites())
+	assert.Eventually(t, func() bool {
+		reason, ok := user1Cb.Declined(invite.Id)
+		return ok && reason == "busy"
+	}, time.Second, 10*time.Millisecond)
+
+	// Or revoked
+	invite, err = sdmUser1.SendInvite(sd1, user3.GetMe(), "player3", InviteOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(sdmUser3.IncomingInvites()))
+	assert.Nil(t, sdmUser1.RevokeInvite(invite.Id))
+	assert.Empty(t, sdmUser3.IncomingInvites())
+	assert.ErrorIs(t, sdmUser1.RevokeInvite(invite.Id), ErrUnknownInvite)
+
+	// Or expires
+	invite, err = sdmUser1.SendInvite(sd1, user3.GetMe(), "player3", InviteOptions{Expiry: 50 * time.Millisecond})
+	assert.Nil(t, err)
+	time.Sleep(100 * time.Millisecond)
+	_, err = sdmUser3.AcceptInvite(invite.Id)
+	assert.ErrorIs(t, err, ErrInviteExpired)
+	assert.Empty(t, sdmUser3.IncomingInvites())
+	assert.Empty(t, sdmUser1.OutgoingInvites())
+	assert.NotContains(t, sd1.(SharedDataProxy).GetInvitees(), "player3")
+}
+
+func TestJoinTokens(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestJoinTokens")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	user3 := common.NewTestMyself("User3", nextPort())
+	user3Cb := NewTestClientCb("user3")
+	sdmUser3 := NewSharedDataManager(ctx.NewCtx("sdm3"), user3, user3Cb, cc)
+	defer sdmUser3.Close()
+	server3 := newLocalListener(ctx, sdmUser3)
+	defer server3.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("board", ".........", DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+
+	_, err := sdmUser2.CreateJoinToken(sd1, "player2", time.Minute)
+	assert.ErrorIs(t, err, ErrUnknownSharedData)
+
+	token, err := sdmUser1.CreateJoinToken(sd1, "player2", time.Minute)
+	assert.Nil(t, err)
+	sd2, err := sdmUser2.JoinWithToken(token)
+	assert.Nil(t, err)
+	assert.Equal(t, sd2, user2Cb.SharedData())
+	assert.Equal(t, "player2", sd2.GetMe())
+	assert.Equal(t, ".........", sd2.Get("board"))
+	assert.Nil(t, sd2.Set("board", "x........"))
+	assert.Equal(t, "x........", sd1.Get("board"))
+
+	// The role is taken
+	_, err = sdmUser3.JoinWithToken(token)
+	assert.ErrorIs(t, err, ErrConflict)
+
+	// Tokens can't be changed
+	claims, payload, signature, err := parseToken(token)
+	assert.Nil(t, err)
+	claims.As = "player3"
+	forged, err := proto.Marshal(claims)
+	assert.Nil(t, err)
+	_, err = sdmUser3.JoinWithToken(encodeToken(forged, signature))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = sdmUser3.JoinWithToken(encodeToken(payload, signature[1:]))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// Or used once expired
+	token, err = sdmUser1.CreateJoinToken(sd1, "player3", -time.Second)
+	assert.Nil(t, err)
+	_, err = sdmUser3.JoinWithToken(token)
+	assert.ErrorIs(t, err, ErrInviteExpired)
+	assert.NotContains(t, sd1.(SharedDataProxy).GetInvitees(), "player3")
+
+	// Any member can make one, the share is still the creator's
+	token, err = sdmUser2.CreateJoinToken(sd2, "player3", time.Minute)
+	assert.Nil(t, err)
+	sd3, err := sdmUser3.JoinWithToken(token)
+	assert.Nil(t, err)
+	assert.Equal(t, user1.GetMe(), sd3.GetCreator())
+	assert.Equal(t, sd1.GetCreator(), sd2.GetCreator())
+	assert.Equal(t, "x........", sd3.Get("board"))
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestConcurrentAccess")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.CreateMap("scores", map[string]interface{}{}, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.CreateArray("moves", []interface{}{}, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.Create("turn", "player1", DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+	assert.True(t, sdmUser1.Invite(osd1, user2.GetMe(), "player2"), "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	remove := sd1.OnDataChangeCB(func(change DataChange) {
+		// Listeners read the shared data while others change it
+		sd1.Get(change.Key)
+		sd1.GetData()
+	})
+	defer remove()
+
+	const writes = 20
+	done := make(chan struct{})
+	var writers sync.WaitGroup
+	for i, sd := range []SharedData{sd1, sd2} {
+		writers.Add(1)
+		go func(i int, sd SharedData) {
+			defer writers.Done()
+			for n := 0; n < writes; n++ {
+				assert.Nil(t, sd.SetMap("scores", fmt.Sprintf("player%d-%d", i+1, n), n))
+				assert.Nil(t, sd.Append("moves", n))
+				assert.Nil(t, sd.Set("turn", sd.GetMe()))
+			}
+		}(i, sd)
+	}
+	var readers sync.WaitGroup
+	for _, sd := range []SharedData{sd1, osd1, sd2} {
+		readers.Add(1)
+		go func(sd SharedData) {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if scores, ok := sd.Get("scores").(map[string]interface{}); ok {
+					// What is handed out is ours to change
+					scores["cheat"] = 100
+				}
+				sd.Len("moves")
+				sd.GetVersioned("turn")
+				for _, d := range sd.GetData() {
+					d.get()
+				}
+			}
+		}(sd)
+	}
+	writers.Wait()
+	close(done)
+	readers.Wait()
+
+	for _, sd := range []SharedData{sd1, sd2} {
+		assert.Eventually(t, func() bool {
+			return sd.Len("scores") == 2*writes && sd.Len("moves") == 2*writes
+		}, 5*time.Second, 10*time.Millisecond)
+		scores := sd.Get("scores").(map[string]interface{})
+		assert.NotContains(t, scores, "cheat")
+	}
+
+	// GetData is a snapshot that later changes leave alone
+	data := sd1.GetData()
+	assert.Nil(t, sd1.SetMap("scores", "late", 1))
+	assert.Nil(t, sd1.Append("moves", "late"))
+	assert.NotContains(t, data["scores"].get(), "late")
+	assert.Len(t, data["moves"].get(), 2*writes)
+	assert.Contains(t, sd1.Get("scores"), "late")
+}
+
+func TestTypedKeys(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestTypedKeys")
+
+	Register[TestUserData]()
+	board := Key[string]("board")
+	moves := ArrayKey[int]("moves")
+	users := MapKey[TestUserData]("users")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, board.Create(osd1, ".........", DefaultGroup, DefaultGroup))
+	assert.Nil(t, moves.Create(osd1, []int{4}, DefaultGroup, DefaultGroup))
+	assert.Nil(t, users.Create(osd1, map[string]TestUserData{"player1": {"user1"}}, DefaultGroup, DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+	assert.True(t, sdmUser1.Invite(osd1, user2.GetMe(), "player2"), "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	// Values that don't fit are refused
+	assert.ErrorIs(t, sd1.Set("board", 5), ErrWrongType)
+	assert.ErrorIs(t, sd1.Append("moves", "x"), ErrWrongType)
+	assert.ErrorIs(t, sd1.SetMap("users", "player2", "user2"), ErrWrongType)
+	assert.ErrorIs(t, Key[int]("board").Declare(sd1), ErrWrongType)
+	value, err := board.Get(sd1)
+	assert.Nil(t, err)
+	assert.Equal(t, ".........", value)
+
+	// Other members read them back as the same types
+	assert.Nil(t, board.Declare(sd2))
+	assert.Nil(t, moves.Declare(sd2))
+	assert.Nil(t, users.Declare(sd2))
+	assert.Nil(t, moves.Append(sd2, 0))
+	assert.Nil(t, users.Set(sd2, "player2", TestUserData{"user2"}))
+	list, err := moves.Get(sd1)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{4, 0}, list)
+	move, err := moves.At(sd1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, move)
+	_, err = moves.At(sd1, 2)
+	assert.ErrorIs(t, err, ErrOutOfRange)
+	user, ok, err := users.Lookup(sd1, "player2")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, TestUserData{"user2"}, user)
+	_, err = Get[int](sd1, "board")
+	assert.ErrorIs(t, err, ErrWrongType)
+	_, err = Get[string](sd1, "missing")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	// Remote changes are checked as they are decoded
+	b, _ := encode(JSONCodec{}, 5)
+	body, _ := proto.Marshal(&pb.SharedDataSet{
+		SharedDataId: "test",
+		Originator:   user1.GetMe().ToPB(),
+		Key:          "board",
+		Value:        b,
+		Codec:        JSONCodecId,
+	})
+	_, status := sdmUser2.OnSharedDataRequest("/shareddata/set", body)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, ".........", sd2.Get("board"))
+
+	// Numbers of another kind are converted
+	b, _ = encode(ProtobufCodec{}, 7)
+	body, _ = proto.Marshal(&pb.SharedDataAppend{
+		SharedDataId: "test",
+		Originator:   user1.GetMe().ToPB(),
+		Key:          "moves",
+		Value:        b,
+		Codec:        ProtobufCodecId,
+	})
+	_, status = sdmUser2.OnSharedDataRequest("/shareddata/append", body)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []interface{}{4, 0, 7}, sd2.Get("moves"))
+}
+
+func TestSchemas(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestSchemas")
+
+	schema := &Schema{
+		Name:    "tictactoe",
+		Version: 2,
+		Strict:  true,
+		Keys: map[string]KeySchema{
+			"board": {Type: TypeOf[string](), Owner: DefaultGroup, Visibility: DefaultGroup},
+			"turn":  {Type: TypeOf[int](), Owner: "player1", Validators: []Validator{InRange(0, 8)}},
+			"moves": {Type: TypeOf[[]int](), Validators: []Validator{InRange(0, 8)}},
+			"mark":  {Validators: []Validator{OneOf("X", "O")}},
+		},
+	}
+	oldSchema := &Schema{Name: "tictactoe", Version: 1}
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	sdmUser2.RegisterSchema(schema)
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	user3 := common.NewTestMyself("User3", nextPort())
+	user3Cb := NewTestClientCb("user3")
+	sdmUser3 := NewSharedDataManager(ctx.NewCtx("sdm3"), user3, user3Cb, cc)
+	defer sdmUser3.Close()
+	sdmUser3.RegisterSchema(oldSchema)
+	server3 := newLocalListener(ctx, sdmUser3)
+	defer server3.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.SetSchema(schema))
+
+	// Keys are checked as they are made and changed
+	assert.Nil(t, osd1.Create("board", ".........", "", ""))
+	assert.Nil(t, osd1.Create("turn", 0, "", DefaultGroup))
+	assert.Nil(t, osd1.CreateArray("moves", []interface{}{4}, DefaultGroup, DefaultGroup))
+	assert.Nil(t, osd1.Create("mark", "X", DefaultGroup, DefaultGroup))
+	assert.Equal(t, DefaultGroup, osd1.GetOwner("board"))
+	assert.Equal(t, DefaultGroup, osd1.GetVisibility("board"))
+	assert.Equal(t, "player1", osd1.GetOwner("turn"))
+	assert.ErrorIs(t, osd1.Create("score", 0, DefaultGroup, DefaultGroup), ErrInvalid)
+	assert.ErrorIs(t, osd1.Set("turn", 9), ErrInvalid)
+	assert.ErrorIs(t, osd1.Set("turn", "9"), ErrWrongType)
+	assert.ErrorIs(t, osd1.Append("moves", 9), ErrInvalid)
+	assert.ErrorIs(t, osd1.Set("mark", "Y"), ErrInvalid)
+	assert.Equal(t, 0, osd1.Get("turn"))
+	sd1 := sdmUser1.Serve(osd1)
+
+	// Only members with the same version of the schema can join
+	assert.False(t, sdmUser1.Invite(osd1, user3.GetMe(), "player2"))
+	_, err := sdmUser1.SendInvite(sd1, user3.GetMe(), "player2", InviteOptions{})
+	assert.ErrorIs(t, err, ErrIncompatibleSchema)
+	assert.True(t, sdmUser1.Invite(osd1, user2.GetMe(), "player2"), "Invite failed")
+	sd2 := user2Cb.SharedData()
+	assert.Equal(t, schema, sd2.GetSchema())
+	assert.Nil(t, sd1.Set("turn", 1))
+	assert.Equal(t, 1, sd2.Get("turn"))
+
+	// Remote changes that don't fit are refused
+	b, _ := encode(JSONCodec{}, 12)
+	body, _ := proto.Marshal(&pb.SharedDataSet{
+		SharedDataId: "test",
+		Originator:   user1.GetMe().ToPB(),
+		Key:          "turn",
+		Value:        b,
+		Codec:        JSONCodecId,
+	})
+	_, status := sdmUser2.OnSharedDataRequest("/shareddata/set", body)
+	assert.Equal(t, http.StatusBadRequest, status)
+	b, _ = encode(JSONCodec{}, 0)
+	body, _ = proto.Marshal(&pb.SharedDataCreate{
+		SharedDataId: "test",
+		Originator:   user1.GetMe().ToPB(),
+		Key:          "score",
+		Value:        b,
+		Codec:        JSONCodecId,
+		Owner:        DefaultGroup,
+	})
+	_, status = sdmUser2.OnSharedDataRequest("/shareddata/create", body)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, 1, sd2.Get("turn"))
+	assert.Equal(t, "", sd2.GetOwner("score"))
+
+	// A schema can't be set on data that doesn't fit it
+	other := NewSharedData(user1.GetMe(), "other")
+	other.SetMe("player1")
+	assert.Nil(t, other.Create("turn", 20, "player1", DefaultGroup))
+	assert.ErrorIs(t, other.SetSchema(schema), ErrInvalid)
+	assert.Nil(t, other.GetSchema())
+	assert.Nil(t, other.Set("turn", 21))
+
+	// Or removed
+	other = NewSharedData(user1.GetMe(), "other")
+	other.SetMe("player1")
+	assert.Nil(t, other.Create("turn", 1, "player1", DefaultGroup))
+	assert.Nil(t, other.SetSchema(schema))
+	assert.ErrorIs(t, other.Set("turn", 20), ErrInvalid)
+	assert.Nil(t, other.SetSchema(nil))
+	assert.Nil(t, other.GetSchema())
+	assert.Nil(t, other.Set("turn", 20))
+}
+
+func TestPaths(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestPaths")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	players := map[string]interface{}{
+		"1": map[string]interface{}{"name": "ann", "hand": []interface{}{"a", "b"}},
+		"2": map[string]interface{}{"name": "bob", "hand": []interface{}{"c"}},
+	}
+	assert.Nil(t, osd1.CreateMap("players", players, "player1", DefaultGroup))
+	assert.Nil(t, osd1.SetPathVisibility("players/1/hand", "player1"))
+	sd1 := sdmUser1.Serve(osd1)
+	assert.True(t, sdmUser1.Invite(osd1, user2.GetMe(), "player2"), "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	appends := make(chan DataChange, 10)
+	sd1.OnDataChangeCB(func(change DataChange) {
+		if change.Kind == AppendChange {
+			appends <- change
+		}
+	})
+
+	// Parts of a key are only sent to members that can see them
+	assert.Equal(t, "ann", sd2.GetPath("players/1/name"))
+	assert.Nil(t, sd2.GetPath("players/1/hand"))
+	assert.Equal(t, []interface{}{"a", "b"}, sd1.GetPath("/players/1/hand"))
+	assert.Equal(t, "player1", sd2.GetPathVisibility("players/1/hand/0"))
+	assert.Equal(t, DefaultGroup, sd2.GetPathVisibility("players/1/name"))
+	assert.Nil(t, sd1.GetPath("players/9"))
+
+	// Owners are inherited by everything below them
+	assert.Nil(t, sd1.SetPathOwner("players/2", "player2"))
+	assert.Equal(t, "player2", sd2.GetPathOwner("players/2/hand"))
+	assert.Equal(t, "player1", sd2.GetPathOwner("players/1/hand"))
+	assert.Nil(t, sd2.AppendPath("players/2/hand", "d"))
+	assert.Equal(t, []interface{}{"c", "d"}, sd1.GetPath("players/2/hand"))
+	select {
+	case change := <-appends:
+		assert.Equal(t, "players", change.Key)
+		assert.Equal(t, "2/hand", change.Path)
+		assert.Equal(t, "d", change.NewValue)
+	case <-time.After(time.Second):
+		t.Fatal("No append")
+	}
+	assert.ErrorIs(t, sd2.SetPath("players/1/name", "eve"), ErrNotOwner)
+	assert.ErrorIs(t, sd1.SetPath("players/2/name", "eve"), ErrNotOwner)
+	assert.ErrorIs(t, sd1.SetPath("players/1/name/first", "eve"), ErrWrongType)
+
+	// Changes carry their paths
+	assert.Nil(t, sd1.SetPath("players/1/hand/0", "z"))
+	assert.Nil(t, sd1.DeletePath("players/1/hand/1"))
+	assert.Nil(t, sd1.SetPath("players/1/a~1b", 3))
+	assert.Equal(t, []interface{}{"z"}, sd1.GetPath("players/1/hand"))
+	assert.Nil(t, sd2.GetPath("players/1/hand"))
+	assert.Equal(t, 3, sd2.GetPath("players/1/a~1b"))
+	assert.Equal(t, 3, sd1.Get("players").(map[string]interface{})["1"].(map[string]interface{})["a/b"])
+	assert.Nil(t, sd1.SetPathVisibility("players/1/hand", DefaultGroup))
+	assert.Equal(t, []interface{}{"z"}, sd2.GetPath("players/1/hand"))
+
+	// And can be undone
+	history := sd2.History("players", time.Time{})
+	assert.Nil(t, sd1.Undo(history[len(history)-1].Id))
+	assert.Equal(t, "player1", sd2.GetPathVisibility("players/1/hand"))
+	var appended HistoryEntry
+	for _, entry := range history {
+		if entry.Kind == AppendChange {
+			appended = entry
+		}
+	}
+	assert.Nil(t, sd2.Undo(appended.Id))
+	assert.Equal(t, []interface{}{"c"}, sd1.GetPath("players/2/hand"))
+}
+
+func TestWatch(t *testing.T) {
+	ctx := common.NewCallCtxWithApp("TestWatch")
+
+	cc := client.NewGrapevineClientCache()
+
+	user1 := common.NewTestMyself("User1", nextPort())
+	user1Cb := NewTestClientCb("user1")
+	sdmUser1 := NewSharedDataManager(ctx.NewCtx("sdm1"), user1, user1Cb, cc)
+	defer sdmUser1.Close()
+	server1 := newLocalListener(ctx, sdmUser1)
+	defer server1.CloseGracefully(0)
+
+	user2 := common.NewTestMyself("User2", nextPort())
+	user2Cb := NewTestClientCb("user2")
+	sdmUser2 := NewSharedDataManager(ctx.NewCtx("sdm2"), user2, user2Cb, cc)
+	defer sdmUser2.Close()
+	server2 := newLocalListener(ctx, sdmUser2)
+	defer server2.CloseGracefully(0)
+
+	osd1 := NewSharedData(user1.GetMe(), "test")
+	osd1.SetMe("player1")
+	assert.Nil(t, osd1.Create("board/0", "x", "player1", DefaultGroup))
+	assert.Nil(t, osd1.Create("board/1", "o", "player1", DefaultGroup))
+	assert.Nil(t, osd1.Create("turn", "player1", "player1", DefaultGroup))
+	sd1 := sdmUser1.Serve(osd1)
+	assert.True(t, sdmUser1.Invite(osd1, user2.GetMe(), "player2"), "Invite failed")
+	sd2 := user2Cb.SharedData()
+
+	assert.Equal(t, []string{"board/0", "board/1"}, sd2.Keys("board/"))
+	assert.Equal(t, []string{"board/0", "board/1", "turn"}, sd2.Keys(""))
+	assert.Empty(t, sd2.Keys("nothing"))
+
+	seen := map[string]interface{}{}
+	sd2.Range("", func(key string, value interface{}) bool {
+		seen[key] = value
+		return key != "board/1"
+	})
+	assert.Equal(t, map[string]interface{}{"board/0": "x", "board/1": "o"}, seen)
+
+	next := func(ch <-chan Change) Change {
+		select {
+		case change := <-ch:
+			return change
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a change")
+		}
+		return Change{}
+	}
+
+	// Watchers start with the keys as they are, then get each change in order
+	watch := sd2.Watch("board/")
+	first := next(watch)
+	assert.Equal(t, map[string]interface{}{"board/0": "x", "board/1": "o"}, first.Snapshot)
+
+	assert.Nil(t, sd1.Set("turn", "player2"))
+	assert.Nil(t, sd1.Set("board/0", "o"))
+	assert.Nil(t, sd1.Create("board/2", "x", "player1", DefaultGroup))
+	assert.Nil(t, sd1.Delete("board/1"))
+
+	change := next(watch)
+	assert.Nil(t, change.Snapshot)
+	assert.Equal(t, SetChange, change.Kind)
+	assert.Equal(t, "board/0", change.Key)
+	assert.Equal(t, "x", change.OldValue)
+	assert.Equal(t, "o", change.NewValue)
+	change = next(watch)
+	assert.Equal(t, CreateChange, change.Kind)
+	assert.Equal(t, "board/2", change.Key)
+	change = next(watch)
+	assert.Equal(t, DeleteChange, change.Kind)
+	assert.Equal(t, "board/1", change.Key)
+
+	// A later watcher's snapshot has everything made before it
+	later := sd1.Watch("")
+	assert.Equal(t, map[string]interface{}{"board/0": "o", "board/2": "x", "turn": "player2"}, next(later).Snapshot)
+	sd1.Unwatch(later)
+	_, open := <-later
+	assert.False(t, open)
+
+	sd2.Unwatch(watch)
+	assert.Nil(t, sd1.Set("board/0", "x"))
+	_, open = <-watch
+	assert.False(t, open)
+	assert.Eventually(t, func() bool { return sd2.Get("board/0") == "x" }, 5*time.Second, 10*time.Millisecond)
+}
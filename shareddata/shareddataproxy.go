@@ -1,50 +1,43 @@
 package shareddata
 
 import (
-	"bytes"
-	"encoding/gob"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/hoyle1974/grapevine/common"
 	pb "github.com/hoyle1974/grapevine/proto"
+	"google.golang.org/protobuf/proto"
 )
 
-type ValueHolder struct {
-	Value interface{}
-}
-
-func init() {
-	gob.Register(ValueHolder{})
-	gob.Register(map[string][]string{})
-}
-
-func toBytes(value interface{}) []byte {
-	var buf bytes.Buffer
-	enc := gob.NewEncoder(&buf)
-	if err := enc.Encode(ValueHolder{value}); err != nil {
-		panic(fmt.Sprintf("toBytes: (%v) : %v", value, err))
-	}
-	return buf.Bytes()
-}
-
-func fromBytes(value []byte) interface{} {
-	buf := bytes.NewBuffer(value)
-	dec := gob.NewDecoder(buf)
-
-	var out ValueHolder
-	if err := dec.Decode(&out); err != nil {
-		panic(fmt.Sprintf("fromBytes: (%v) : %v", value, err))
-	}
-
-	return out.Value
-}
-
 type SharedDataProxy interface {
 	SharedData
 	GetOrigin() SharedData
 	AddInvitee(recipient common.Contact, as string)
-	SendStateTo(recipient common.Contact)
+	RemoveInvitee(as string)
+	GetInvitees() map[string]common.Contact
+	SendStateTo(recipient common.Contact) error
+	getState(as string) (*pb.SharedDataSendState, error)
+	getStateSince(as string, have map[string]uint64, after string, limit int) (*pb.SharedDataSendState, string, error)
+	sendStateSince(recipient common.Contact, have map[string]uint64) error
+	memberOf(contact common.Contact) (member, bool)
+	receive(originator member, seq uint64, op func() error) error
+	removeInvitee(as string)
+	resetMail(as string)
+	withLock(f func())
+	leave() error
+	stop()
+	snapshot()
+	renew(as string)
+	ownershipRequested(originator member, key string) error
+	reassigned(originator member, key string, previous string, owner string) error
+	hostChanged(as string, contact common.Contact)
+	hostTaken(originator member, previous string) error
+	decide(originator member, key string, version uint64, value interface{}) (*delivery, error)
+	decided(originator member, author common.Contact, key string, version uint64, value interface{}) error
 }
 
 func NewSharedDataProxy(origin SharedData, sdm *sharedDataManager) SharedDataProxy {
@@ -52,65 +45,492 @@ func NewSharedDataProxy(origin SharedData, sdm *sharedDataManager) SharedDataPro
 	if origin.IsProxy() {
 		return nil
 	}
-
-	return &sharedDataProxy{
+	p := &sharedDataProxy{
+		lock:     &sync.RWMutex{},
 		origin:   origin,
 		sdm:      sdm,
 		invities: make(map[string]common.Contact),
+		outboxes: make(map[string]*outbox),
+		inboxes:  make(map[string]*inbox),
+		seen:     make(map[string]time.Time),
+		done:     make(chan struct{}),
+		lease:    leaseDuration,
+	}
+	if sd, ok := origin.(*sharedData); ok {
+		// Remote changes are made straight to the origin, so they and our
+		// own are made under its lock
+		p.lock = &sd.lock
+		sd.local = sdm.GetMe()
+		sd.listeners.addJournal(p.persist)
 	}
+	go p.heartbeat(heartbeatInterval)
+	return p
 }
 
-func (p *sharedDataProxy) SendStateTo(recipient common.Contact) {
-	p.lock.Lock()
-	defer p.lock.Unlock()
+// stateChunkSize is roughly how many bytes of keys are sent at once when
+// sending the state of a shared data, bigger states are sent in parts.
+var stateChunkSize = 256 * 1024
+
+// getState builds a copy of the shared data for the member playing the role
+// as, leaving out the values of keys it can't see.
+func (p *sharedDataProxy) getState(as string) (*pb.SharedDataSendState, error) {
+	state, _, err := p.getStateSince(as, nil, "", 0)
+	return state, err
+}
 
-	req := pb.SharedDataSendState{
+// getStateSince builds the part of the state the member playing the role as
+// is missing, given the versions of the keys it has, or all of it if have
+// is nil.  Keys are sent in order, starting after the key after, until
+// about limit bytes have been added.  If there is more to send the last key
+// added is returned to carry on from.  Everything else is only sent with the
+// first part.
+func (p *sharedDataProxy) getStateSince(as string, have map[string]uint64, after string, limit int) (*pb.SharedDataSendState, string, error) {
+	state := &pb.SharedDataSendState{
 		SharedDataId: string(p.origin.GetId()),
 		Originator:   p.sdm.GetMe().ToPB(),
 		Data:         make(map[string]*pb.SharedDataData),
 		Listeners:    make(map[string]*pb.UserContact),
+		Groups:       make(map[string]*pb.SharedDataGroup),
+		Partial:      have != nil || after != "",
 	}
 
-	for key, value := range p.GetOrigin().GetData() {
-		data := &pb.SharedDataData{
-			Value:     toBytes(value.value),
-			Owner:     value.owner,
-			Visbility: value.visibility,
+	origin := originOf(p)
+	state.Host = origin.host
+	state.Creator = origin.creator.ToPB()
+	state.Schema, state.SchemaVersion = schemaOf(origin)
+	members := &origin.members
+	codec := p.sdm.GetCodec()
+
+	keys := make([]string, 0, len(origin.data))
+	for key, value := range origin.data {
+		if version, ok := have[key]; ok && version >= value.version {
+			continue
+		}
+		if after == "" || key > after {
+			keys = append(keys, key)
 		}
-		req.Data[key] = data
 	}
+	sort.Strings(keys)
 
-	for key, value := range p.invities {
-		contact := value.ToPB()
-		req.Listeners[key] = contact
+	if after == "" {
+		for _, entry := range origin.history.since("", time.Time{}) {
+			if version, ok := have[entry.Key]; ok && version >= entry.Version {
+				continue
+			}
+			if entry.Kind == DeleteChange {
+				if _, ok := have[entry.Key]; ok {
+					if _, exists := origin.data[entry.Key]; !exists {
+						state.Deleted = append(state.Deleted, entry.Key)
+					}
+				}
+			}
+			e, err := encodeHistory(codec, entry, members.canSee(entry.Visibility, as))
+			if err != nil {
+				return nil, "", err
+			}
+			state.History = append(state.History, e)
+		}
+
+		for key, value := range p.invities {
+			contact := value.ToPB()
+			state.Listeners[key] = contact
+		}
+
+		for group, roles := range members.groups {
+			state.Groups[group] = &pb.SharedDataGroup{Roles: roles}
+		}
 	}
 
-	resp := pb.SharedDataSendStateResponse{}
-	err := p.sdm.clientCache.POST(recipient.Address, "/shareddata/sendstate", &req, &resp)
-	if err != nil {
-		panic(err)
+	size := 0
+	for i, key := range keys {
+		if limit > 0 && size >= limit {
+			return state, keys[i-1], nil
+		}
+		value := origin.data[key]
+		if len(value.visibilities) > 0 {
+			value = value.withRoot(origin.hideFrom(value, nil, value.raw(), as))
+		}
+		data, err := encodeData(codec, value, members.canSee(value.visibility, as))
+		if err != nil {
+			return nil, "", err
+		}
+		state.Data[key] = data
+		size += len(key) + proto.Size(data)
 	}
+	return state, "", nil
+}
+
+// refused undoes our change msg, which a member refused, by fetching the
+// keys it changed back from the host.  The host keeps its own copy, it is
+// up to the others to catch up with it.
+func (p *sharedDataProxy) refused(msg proto.Message) {
+	log := p.sdm.ctx.NewCtx("refused")
 
+	keys := changedKeys(msg)
+	if len(keys) == 0 {
+		return
+	}
+
+	p.lock.RLock()
+	origin := originOf(p)
+	host, ok := p.invities[origin.host]
+	hosting := !ok || origin.members.includes(origin.host, origin.me)
+	// Only the keys that were refused are sent
+	have := make(map[string]uint64, len(origin.data))
+	for key := range origin.data {
+		have[key] = math.MaxUint64
+	}
+	versions := make(map[string]uint64, len(keys))
+	for _, key := range keys {
+		have[key] = 0
+		versions[key] = origin.data[key].version
+	}
+	p.lock.RUnlock()
+	if hosting {
+		return
+	}
+
+	req := pb.SharedDataGetState{
+		SharedDataId: string(p.origin.GetId()),
+		Originator:   p.sdm.GetMe().ToPB(),
+		Versions:     have,
+	}
+	resp := pb.SharedDataGetStateResponse{}
+	if err := p.sdm.clientCache.POST(host.Address, "/shareddata/getstate", &req, &resp); err != nil {
+		log.Warn().Err(err).Msgf("Could not fetch %v back from %v", keys, origin.host)
+		return
+	}
+	values := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		if value, ok := resp.State.GetData()[key]; ok {
+			v, err := decodeCRDT(value.Codec, value.Value)
+			if err != nil {
+				log.Warn().Err(err).Msgf("Could not fetch %v back from %v", key, origin.host)
+				return
+			}
+			values[key] = v
+		}
+	}
+
+	p.withLock(func() {
+		origin := originOf(p)
+		originator := member{host, origin.host}
+		// Like loading the state, this isn't a change of its own
+		origin.history.loading(func() {
+			for _, key := range keys {
+				// A change made since will be sent to the host after this one
+				if origin.data[key].version != versions[key] {
+					continue
+				}
+				if value, ok := resp.State.GetData()[key]; ok {
+					origin.load(originator, key, decodeData(value, values[key]))
+				} else if resp.Next == "" {
+					origin.remove(originator, key)
+				}
+			}
+		})
+	})
+}
+
+func (p *sharedDataProxy) SendStateTo(recipient common.Contact) error {
+	return p.sendStateSince(recipient, nil)
+}
+
+// sendStateSince sends recipient the part of the state it is missing, given
+// the versions of the keys it has, in as many parts as it takes.
+func (p *sharedDataProxy) sendStateSince(recipient common.Contact, have map[string]uint64) error {
+	after := ""
+	for {
+		p.lock.Lock()
+		as, _ := p.roleOf(recipient)
+		req, next, err := p.getStateSince(as, have, after, stateChunkSize)
+		p.lock.Unlock()
+		if err != nil {
+			return err
+		}
+
+		resp := pb.SharedDataSendStateResponse{}
+		if err := p.sdm.clientCache.POST(recipient.Address, "/shareddata/sendstate", req, &resp); err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		after = next
+	}
+}
+
+// versions returns the version of every key, for getStateSince.
+func (s *sharedData) versions() map[string]uint64 {
+	versions := make(map[string]uint64, len(s.data))
+	for key, d := range s.data {
+		versions[key] = d.version
+	}
+	return versions
 }
 
 func (p *sharedDataProxy) AddInvitee(recipient common.Contact, as string) {
+	defer p.records.write()
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
+	current, known := p.invities[as]
 	p.invities[as] = recipient
+	originOf(p).members.addRole(as)
+	p.renew(as)
+	// Only someone new, or back from somewhere else, starts numbering their
+	// changes from scratch, those we are still exchanging must stay in order
+	if !known || current.AccountId != recipient.AccountId || !current.Address.Equal(recipient.Address) {
+		p.resetMail(as)
+	}
+	p.snapshot()
+}
+
+// RemoveInvitee stops sending updates to the member playing the role as and
+// hands any keys it owned over to the default group.
+func (p *sharedDataProxy) RemoveInvitee(as string) {
+	defer p.records.write()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.removeInvitee(as)
+}
+
+func (p *sharedDataProxy) removeInvitee(as string) {
+	leaver := member{p.invities[as], as}
+	delete(p.invities, as)
+	delete(p.seen, as)
+	p.resetMail(as)
+
+	origin := originOf(p)
+	for key, value := range origin.data {
+		if value.owner == as {
+			origin.changeDataOwner(leaver, key, DefaultGroup)
+		}
+	}
+	origin.members.removeRole(as)
+	p.snapshot()
+}
+
+// memberOf finds the role contact plays in this shared data.
+func (p *sharedDataProxy) memberOf(contact common.Contact) (member, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	as, ok := p.roleOf(contact)
+	return member{contact, as}, ok
+}
+
+func (p *sharedDataProxy) roleOf(contact common.Contact) (string, bool) {
+	for as, invitee := range p.invities {
+		if invitee.AccountId == contact.AccountId && invitee.Address.Equal(contact.Address) {
+			return as, true
+		}
+	}
+	return "", false
+}
+
+// broadcast queues req for every other member that can see visibility, and
+// hidden, if it isn't nil, for those that can't.  It must be called with
+// the lock held so changes are numbered in the order they were made.
+func (p *sharedDataProxy) broadcast(uri string, visibility string, req proto.Message, hidden proto.Message, resp proto.Message) *delivery {
+	members := &originOf(p).members
+	return p.broadcastEach(uri, func(as string) proto.Message {
+		if members.canSee(visibility, as) {
+			return req
+		}
+		return hidden
+	}, resp)
+}
+
+// broadcastEach is broadcast for changes that differ for each member, msgFor
+// returns what to send to as, or nil to send nothing.
+func (p *sharedDataProxy) broadcastEach(uri string, msgFor func(as string) proto.Message, resp proto.Message) *delivery {
+	d := &delivery{}
+	for as, invitee := range p.invities {
+		if as == p.GetMe() {
+			continue
+		}
+		msg := msgFor(as)
+		if msg == nil {
+			continue
+		}
+		o := p.outboxFor(as, invitee)
+		d.add(as, o, o.queue(uri, msg, resp))
+	}
+	return d
+}
+
+// change makes a local change with the lock held, and then, without it, so
+// members can send us their own changes meanwhile, sends it to the others.
+// It returns a *DeliveryError if any of them could not be reached.
+func (p *sharedDataProxy) change(apply func() (*delivery, error)) error {
+	p.lock.Lock()
+	d, err := apply()
+	p.lock.Unlock()
+	p.records.write()
+	if err != nil {
+		return err
+	}
+	return d.send()
+}
+
+func (p *sharedDataProxy) GetInvitees() map[string]common.Contact {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	invitees := make(map[string]common.Contact, len(p.invities))
+	for key, value := range p.invities {
+		invitees[key] = value
+	}
+	return invitees
 }
 
 type sharedDataProxy struct {
-	lock     sync.Mutex
+	lock     *sync.RWMutex // The lock of the origin
 	origin   SharedData
 	sdm      *sharedDataManager
 	invities map[string]common.Contact
+	mail     sync.Mutex
+	outboxes map[string]*outbox
+	inboxes  map[string]*inbox
+	logged   int // Records stored since the last snapshot
+	records  recordQueue
+	seen     map[string]time.Time
+	done     chan struct{}
+	stopping sync.Once
+	lease    time.Duration
+}
+
+func (p *sharedDataProxy) outboxFor(as string, invitee common.Contact) *outbox {
+	p.mail.Lock()
+	defer p.mail.Unlock()
+
+	o, ok := p.outboxes[as]
+	if !ok {
+		o = newOutbox(p.sdm.ctx.NewCtx("outbox:"+as), p.sdm.clientCache, invitee, p.refused)
+		p.outboxes[as] = o
+	}
+	return o
+}
+
+// resetMail forgets the changes sent to and received from the member playing as.
+func (p *sharedDataProxy) resetMail(as string) {
+	p.mail.Lock()
+	defer p.mail.Unlock()
+
+	if o, ok := p.outboxes[as]; ok {
+		o.close()
+		delete(p.outboxes, as)
+	}
+	delete(p.inboxes, as)
+}
+
+// receive applies op, the change originator numbered seq, with the lock held
+// and in the order originator made its changes.  Changes without a number
+// are applied straight away.
+func (p *sharedDataProxy) receive(originator member, seq uint64, op func() error) error {
+	locked := func() error {
+		defer p.records.write()
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		p.renew(originator.as)
+		return op()
+	}
+	if seq == 0 {
+		return locked()
+	}
+
+	p.mail.Lock()
+	i, ok := p.inboxes[originator.as]
+	if !ok {
+		i = newInbox(p.sdm.ctx.NewCtx("inbox:" + originator.as))
+		p.inboxes[originator.as] = i
+	}
+	p.mail.Unlock()
+
+	return i.deliver(seq, locked)
+}
+
+// withLock runs f with the lock held, anything it changed is written once
+// the lock is released.
+func (p *sharedDataProxy) withLock(f func()) {
+	defer p.records.write()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	f()
+}
+
+// leave tells the other members we are gone and stops sending them changes,
+// members that can't be reached right now won't find out.
+func (p *sharedDataProxy) leave() error {
+	err := p.change(func() (*delivery, error) {
+		req := pb.SharedDataLeave{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			As:           p.origin.GetMe(),
+		}
+		resp := pb.SharedDataLeaveResponse{}
+		return p.broadcast("/shareddata/leave", "", &req, nil, &resp), nil
+	})
+	p.stop()
+	return err
+}
+
+// stop ends our heartbeats and stops sending changes, without telling the
+// other members.  What is waiting to be stored is written first.
+func (p *sharedDataProxy) stop() {
+	p.stopping.Do(func() { close(p.done) })
+	p.records.write()
+
+	p.mail.Lock()
+	defer p.mail.Unlock()
+	for as, o := range p.outboxes {
+		o.close()
+		delete(p.outboxes, as)
+	}
+}
+
+// encodeData encodes d to be sent, with its value only if visible.
+func encodeData(codec Codec, d data, visible bool) (*pb.SharedDataData, error) {
+	data := &pb.SharedDataData{
+		Owner:        d.owner,
+		Visbility:    d.visibility,
+		Successor:    d.successor,
+		Owners:       d.owners,
+		Visibilities: d.visibilities,
+	}
+	if visible {
+		b, err := encode(codec, d.raw())
+		if err != nil {
+			return nil, err
+		}
+		data.Value = b
+		data.Codec = codec.Id()
+		data.Version = d.version
+	}
+	return data, nil
+}
+
+// decodeData is the data encodeData sent as pbData, whose value decoded to
+// value.
+func decodeData(pbData *pb.SharedDataData, value interface{}) data {
+	d := newData(value, pbData.Owner, pbData.Visbility)
+	d.version = pbData.Version
+	d.successor = pbData.Successor
+	d.owners, d.visibilities = pbData.Owners, pbData.Visibilities
+	return d
 }
 
 func (p *sharedDataProxy) GetOrigin() SharedData {
 	return p.origin
 }
 
+// originOf returns the local copy behind proxy that remote changes are applied to.
+func originOf(proxy SharedDataProxy) *sharedData {
+	return proxy.GetOrigin().(*sharedData)
+}
+
 func (s *sharedDataProxy) GetData() map[string]data {
 	return s.origin.GetData()
 }
@@ -127,135 +547,538 @@ func (p *sharedDataProxy) GetId() SharedDataId {
 	return p.origin.GetId()
 }
 
-func (p *sharedDataProxy) Create(key string, value interface{}, owner string, visibility string) {
-	p.lock.Lock()
-	defer p.lock.Unlock()
+func (p *sharedDataProxy) Create(key string, value interface{}, owner string, visibility string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		b, err := encode(codec, value)
+		if err != nil {
+			return nil, err
+		}
+		if err := origin.create(origin.self(), key, value, owner, visibility); err != nil {
+			return nil, err
+		}
+		// The schema may have chosen them
+		owner, visibility = origin.data[key].owner, origin.data[key].visibility
 
-	p.origin.Create(key, value, owner, visibility)
+		req := pb.SharedDataCreate{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Value:        b,
+			Codec:        codec.Id(),
+			Owner:        owner,
+			Visibility:   visibility,
+		}
+		resp := pb.SharedDataCreateResponse{}
+		// Members that can't see the value still learn about the key
+		hidden := proto.Clone(&req).(*pb.SharedDataCreate)
+		hidden.Value = nil
+		return p.broadcast("/shareddata/create", visibility, &req, hidden, &resp), nil
+	})
+}
 
-	req := pb.SharedDataCreate{
-		SharedDataId: string(p.origin.GetId()),
-		Originator:   p.sdm.GetMe().ToPB(),
-		Key:          key,
-		Value:        toBytes(value),
-		Owner:        owner,
-		Visibility:   visibility,
-	}
-	resp := pb.SharedDataCreateResponse{}
-	for key, value := range p.invities {
-		if key != p.GetMe() {
-			p.sdm.clientCache.POST(value.Address, "/shareddata/create", &req, &resp)
+func (p *sharedDataProxy) CreateArray(key string, value []interface{}, owner string, visibility string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		b, err := encode(codec, value)
+		if err != nil {
+			return nil, err
 		}
-	}
+		if err := origin.createArray(origin.self(), key, value, owner, visibility); err != nil {
+			return nil, err
+		}
+		// The schema may have chosen them
+		owner, visibility = origin.data[key].owner, origin.data[key].visibility
+
+		req := pb.SharedDataCreateArray{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Value:        b,
+			Codec:        codec.Id(),
+			Owner:        owner,
+			Visibility:   visibility,
+		}
+		resp := pb.SharedDataCreateArrayResponse{}
+		// Members that can't see the value still learn about the key
+		hidden := proto.Clone(&req).(*pb.SharedDataCreateArray)
+		hidden.Value = nil
+		return p.broadcast("/shareddata/createarray", visibility, &req, hidden, &resp), nil
+	})
 }
 
-func (p *sharedDataProxy) CreateArray(key string, value []interface{}, owner string, visibility string) {
-	p.lock.Lock()
-	defer p.lock.Unlock()
+func (p *sharedDataProxy) CreateMap(key string, value interface{}, owner string, visibility string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		b, err := encode(codec, value)
+		if err != nil {
+			return nil, err
+		}
+		if err := origin.createMap(origin.self(), key, value, owner, visibility); err != nil {
+			return nil, err
+		}
+		// The schema may have chosen them
+		owner, visibility = origin.data[key].owner, origin.data[key].visibility
 
-	p.origin.CreateArray(key, value, owner, visibility)
+		req := pb.SharedDataCreateMap{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Value:        b,
+			Codec:        codec.Id(),
+			Owner:        owner,
+			Visibility:   visibility,
+		}
+		resp := pb.SharedDataCreateMapResponse{}
+		// Members that can't see the value still learn about the key
+		hidden := proto.Clone(&req).(*pb.SharedDataCreateMap)
+		hidden.Value = nil
+		return p.broadcast("/shareddata/createmap", visibility, &req, hidden, &resp), nil
+	})
+}
 
-	req := pb.SharedDataCreateArray{
-		SharedDataId: string(p.origin.GetId()),
-		Originator:   p.sdm.GetMe().ToPB(),
-		Key:          key,
-		Value:        toBytes(value),
-		Owner:        owner,
-		Visibility:   visibility,
-	}
-	resp := pb.SharedDataCreateArrayResponse{}
-	for key, value := range p.invities {
-		if key != p.GetMe() {
-			p.sdm.clientCache.POST(value.Address, "/shareddata/create", &req, &resp)
+func (p *sharedDataProxy) CreateCRDT(key string, kind CRDTKind, owner string, visibility string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.createCRDT(origin.self(), key, kind, owner, visibility); err != nil {
+			return nil, err
 		}
-	}
+		// The schema may have chosen them
+		owner, visibility = origin.data[key].owner, origin.data[key].visibility
+		codec := p.sdm.GetCodec()
+		b, err := encode(codec, origin.crdtOf(key))
+		if err != nil {
+			return nil, err
+		}
+
+		req := pb.SharedDataCreate{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Value:        b,
+			Codec:        codec.Id(),
+			Owner:        owner,
+			Visibility:   visibility,
+		}
+		resp := pb.SharedDataCreateResponse{}
+		// Members that can't see the value still learn about the key
+		hidden := proto.Clone(&req).(*pb.SharedDataCreate)
+		hidden.Value = nil
+		return p.broadcast("/shareddata/create", visibility, &req, hidden, &resp), nil
+	})
 }
 
-func (p *sharedDataProxy) CreateMap(key string, value interface{}, owner string, visibility string) {
-	p.lock.Lock()
-	defer p.lock.Unlock()
+func (p *sharedDataProxy) Increment(key string, delta int64) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.increment(origin.self(), key, delta); err != nil {
+			return nil, err
+		}
+		return p.sendMerge(key)
+	})
+}
+
+func (p *sharedDataProxy) AddToSet(key string, value interface{}) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if _, err := encode(p.sdm.GetCodec(), value); err != nil {
+			return nil, err
+		}
+		if err := origin.addToSet(origin.self(), key, value); err != nil {
+			return nil, err
+		}
+		return p.sendMerge(key)
+	})
+}
+
+func (p *sharedDataProxy) RemoveFromSet(key string, value interface{}) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.removeFromSet(origin.self(), key, value); err != nil {
+			return nil, err
+		}
+		return p.sendMerge(key)
+	})
+}
 
-	p.origin.CreateMap(key, value, owner, visibility)
+// sendMerge sends our copy of the crdt key to the members that can see it
+// so they can merge it into theirs.
+func (p *sharedDataProxy) sendMerge(key string) (*delivery, error) {
+	codec := p.sdm.GetCodec()
+	b, err := encode(codec, originOf(p).crdtOf(key))
+	if err != nil {
+		return nil, err
+	}
 
-	req := pb.SharedDataCreateMap{
+	req := pb.SharedDataMerge{
 		SharedDataId: string(p.origin.GetId()),
 		Originator:   p.sdm.GetMe().ToPB(),
 		Key:          key,
-		Value:        toBytes(value),
-		Owner:        owner,
-		Visibility:   visibility,
-	}
-	resp := pb.SharedDataCreateMapResponse{}
-	for key, value := range p.invities {
-		if key != p.GetMe() {
-			p.sdm.clientCache.POST(value.Address, "/shareddata/create", &req, &resp)
-		}
+		Value:        b,
+		Codec:        codec.Id(),
 	}
+	resp := pb.SharedDataMergeResponse{}
+	return p.broadcast("/shareddata/merge", originOf(p).data[key].visibility, &req, nil, &resp), nil
 }
 
 func (p *sharedDataProxy) Get(key string) interface{} {
 	return p.origin.Get(key)
 }
 
-func (p *sharedDataProxy) Set(key string, value interface{}) {
-	p.lock.Lock()
-	defer p.lock.Unlock()
-
-	p.origin.Set(key, value)
+func (p *sharedDataProxy) Set(key string, value interface{}) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		b, err := encode(codec, value)
+		if err != nil {
+			return nil, err
+		}
+		if err := origin.set(origin.self(), key, value); err != nil {
+			return nil, err
+		}
+		if origin.crdtOf(key) != nil {
+			return p.sendMerge(key)
+		}
 
-	req := pb.SharedDataSet{
-		SharedDataId: string(p.origin.GetId()),
-		Originator:   p.sdm.GetMe().ToPB(),
-		Key:          key,
-		Value:        toBytes(value),
-	}
-	resp := pb.SharedDataSetResponse{}
-	for key, value := range p.invities {
-		if key != p.GetMe() {
-			p.sdm.clientCache.POST(value.Address, "/shareddata/set", &req, &resp)
+		req := pb.SharedDataSet{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Value:        b,
+			Codec:        codec.Id(),
 		}
+		resp := pb.SharedDataSetResponse{}
+		return p.broadcast("/shareddata/set", origin.data[key].visibility, &req, nil, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) GetVersioned(key string) (interface{}, uint64) {
+	return p.origin.GetVersioned(key)
+}
+
+// CompareAndSet has the host decide if key is still at version, so when
+// members try at once only one of them succeeds.  The host sends the change
+// to everyone, we have it by the time it returns.
+func (p *sharedDataProxy) CompareAndSet(key string, version uint64, value interface{}) error {
+	return p.compareAndSet(key, version, value)
+}
+
+func (p *sharedDataProxy) SetIf(key string, value interface{}, predicate func(current interface{}) bool) error {
+	p.lock.RLock()
+	d := originOf(p).data[key]
+	ok := predicate(copyValue(d.get()))
+	p.lock.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrConflict, key)
 	}
+	return p.compareAndSet(key, d.version, value)
 }
 
-func (p *sharedDataProxy) SetMap(key string, mapKey string, value interface{}) {
-	p.lock.Lock()
-	defer p.lock.Unlock()
+func (p *sharedDataProxy) compareAndSet(key string, version uint64, value interface{}) error {
+	codec := p.sdm.GetCodec()
+	b, err := encode(codec, value)
+	if err != nil {
+		return err
+	}
 
-	p.origin.SetMap(key, mapKey, value)
+	p.lock.RLock()
+	origin := originOf(p)
+	me := origin.self()
+	host, ok := p.invities[origin.host]
+	hosting := !ok || origin.members.includes(origin.host, origin.me)
+	p.lock.RUnlock()
+	if hosting {
+		d, err := p.decide(me, key, version, value)
+		if err != nil {
+			return err
+		}
+		return d.send()
+	}
 
-	req := pb.SharedDataSetMap{
+	req := pb.SharedDataCompareAndSet{
 		SharedDataId: string(p.origin.GetId()),
 		Originator:   p.sdm.GetMe().ToPB(),
 		Key:          key,
-		MapKey:       mapKey,
-		Value:        toBytes(value),
+		Value:        b,
+		Codec:        codec.Id(),
+		Version:      version,
 	}
-	resp := pb.SharedDataSetMapResponse{}
-	for key, value := range p.invities {
-		if key != p.GetMe() {
-			p.sdm.clientCache.POST(value.Address, "/shareddata/setmap", &req, &resp)
+	resp := pb.SharedDataCompareAndSetResponse{}
+	if err := p.sdm.clientCache.POST(host.Address, "/shareddata/compareandset", &req, &resp); err != nil {
+		if isRefused(err) {
+			return refusal(err)
 		}
+		return err
 	}
+	return nil
 }
 
-func (p *sharedDataProxy) Append(key string, value interface{}) {
+// decide makes a compare and set by originator, as the host, and returns
+// the change to send to the other members.  Nothing is sent here, so a
+// caller holding the manager's lock can release it before talking to them.
+func (p *sharedDataProxy) decide(originator member, key string, version uint64, value interface{}) (*delivery, error) {
+	codec := p.sdm.GetCodec()
+	b, err := encode(codec, value)
+	if err != nil {
+		return nil, err
+	}
+
+	defer p.records.write()
 	p.lock.Lock()
 	defer p.lock.Unlock()
-
-	req := pb.SharedDataAppend{
+	origin := originOf(p)
+	if origin.host != "" && !origin.members.includes(origin.host, origin.me) {
+		return nil, fmt.Errorf("%w: %v is hosted by %v", ErrConflict, origin.id, origin.host)
+	}
+	p.renew(originator.as)
+	if err := origin.compareAndSet(originator, key, version, value); err != nil {
+		return nil, err
+	}
+	req := pb.SharedDataCompareAndSet{
 		SharedDataId: string(p.origin.GetId()),
 		Originator:   p.sdm.GetMe().ToPB(),
+		Author:       originator.contact.ToPB(),
 		Key:          key,
-		Value:        toBytes(value),
+		Value:        b,
+		Codec:        codec.Id(),
+		Version:      version,
 	}
-	resp := pb.SharedDataAppendResponse{}
-	for key, value := range p.invities {
-		if key != p.GetMe() {
-			p.sdm.clientCache.POST(value.Address, "/shareddata/append", &req, &resp)
+	resp := pb.SharedDataCompareAndSetResponse{}
+	return p.broadcast("/shareddata/compareandset", origin.data[key].visibility, &req, nil, &resp), nil
+}
+
+// decided applies a compare and set by author that originator, the host,
+// decided on.  It is called with the lock held.
+func (p *sharedDataProxy) decided(originator member, author common.Contact, key string, version uint64, value interface{}) error {
+	origin := originOf(p)
+	if originator.as != origin.host {
+		return fmt.Errorf("%w: only the host, %v, decides a compare and set", ErrNotOwner, origin.host)
+	}
+	as, ok := p.roleOf(author)
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrNotMember, author)
+	}
+	return origin.compareAndSet(member{author, as}, key, version, value)
+}
+
+func (p *sharedDataProxy) SetMap(key string, mapKey string, value interface{}) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		b, err := encode(codec, value)
+		if err != nil {
+			return nil, err
+		}
+		if err := origin.setMap(origin.self(), key, mapKey, value); err != nil {
+			return nil, err
 		}
+		if origin.crdtOf(key) != nil {
+			return p.sendMerge(key)
+		}
+
+		req := pb.SharedDataSetMap{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			MapKey:       mapKey,
+			Value:        b,
+			Codec:        codec.Id(),
+		}
+		resp := pb.SharedDataSetMapResponse{}
+		return p.broadcast("/shareddata/setmap", origin.data[key].visibility, &req, nil, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) Append(key string, value interface{}) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		b, err := encode(codec, value)
+		if err != nil {
+			return nil, err
+		}
+		if err := origin.appendValue(origin.self(), key, value); err != nil {
+			return nil, err
+		}
+		if origin.crdtOf(key) != nil {
+			return p.sendMerge(key)
+		}
+
+		req := pb.SharedDataAppend{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Value:        b,
+			Codec:        codec.Id(),
+		}
+		resp := pb.SharedDataAppendResponse{}
+		return p.broadcast("/shareddata/append", origin.data[key].visibility, &req, nil, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) InsertAt(key string, index int, value interface{}) error {
+	return p.Splice(key, index, 0, value)
+}
+
+func (p *sharedDataProxy) RemoveAt(key string, index int) error {
+	return p.Splice(key, index, 1)
+}
+
+func (p *sharedDataProxy) Splice(key string, index int, count int, values ...interface{}) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		b, err := encode(codec, values)
+		if err != nil {
+			return nil, err
+		}
+		if err := origin.splice(origin.self(), key, index, count, values); err != nil {
+			return nil, err
+		}
+		if origin.crdtOf(key) != nil {
+			return p.sendMerge(key)
+		}
+
+		req := pb.SharedDataSplice{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Index:        int64(index),
+			Count:        int64(count),
+			Values:       b,
+			Codec:        codec.Id(),
+		}
+		resp := pb.SharedDataSpliceResponse{}
+		return p.broadcast("/shareddata/splice", origin.data[key].visibility, &req, nil, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) DeleteMapKey(key string, mapKey string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.deleteMapKey(origin.self(), key, mapKey); err != nil {
+			return nil, err
+		}
+		if origin.crdtOf(key) != nil {
+			return p.sendMerge(key)
+		}
+
+		req := pb.SharedDataDeleteMapKey{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			MapKey:       mapKey,
+		}
+		resp := pb.SharedDataDeleteMapKeyResponse{}
+		return p.broadcast("/shareddata/deletemapkey", origin.data[key].visibility, &req, nil, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) Delete(key string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.deleteKey(origin.self(), key); err != nil {
+			return nil, err
+		}
+
+		req := pb.SharedDataDelete{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+		}
+		resp := pb.SharedDataDeleteResponse{}
+		// Everyone knew about the key, even if they couldn't see it
+		return p.broadcast("/shareddata/delete", "", &req, nil, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) Len(key string) int {
+	return p.origin.Len(key)
+}
+
+func (p *sharedDataProxy) History(key string, since time.Time) []HistoryEntry {
+	return p.origin.History(key, since)
+}
+
+// Undo reverses the change id through the proxy, so the other members see
+// it undone too.
+func (p *sharedDataProxy) Undo(id OpId) error {
+	entry, err := originOf(p).history.find(id)
+	if err != nil {
+		return err
 	}
+	return undo(p, entry)
+}
 
-	p.origin.Append(key, value)
+func (p *sharedDataProxy) Transaction(f func(tx Tx) error) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		var ops []*pb.SharedDataOp
+		var visibility []string
+		prepare := func(txOps []txOp) error {
+			merged := make(map[string]bool)
+			for _, op := range txOps {
+				value := op.value
+				if c := origin.crdtOf(op.key); c != nil && op.op != "changeowner" {
+					// Other members merge our copy rather than repeat the change
+					if merged[op.key] {
+						continue
+					}
+					merged[op.key] = true
+					op = txOp{op: "merge", key: op.key}
+					value = c
+				}
+				pbOp := &pb.SharedDataOp{
+					Op:         op.op,
+					Key:        op.key,
+					MapKey:     op.mapKey,
+					Owner:      op.owner,
+					Visibility: op.visibility,
+				}
+				if value != nil {
+					b, err := encode(codec, value)
+					if err != nil {
+						return err
+					}
+					pbOp.Value = b
+					pbOp.Codec = codec.Id()
+				}
+				ops = append(ops, pbOp)
+				visibility = append(visibility, origin.data[op.key].visibility)
+			}
+			return nil
+		}
+		if err := origin.transaction(origin.self(), func(tx *transaction) error { return f(tx) }, prepare); err != nil {
+			return nil, err
+		}
+
+		resp := pb.SharedDataTransactionResponse{}
+		return p.broadcastEach("/shareddata/transaction", func(as string) proto.Message {
+			req := &pb.SharedDataTransaction{
+				SharedDataId: string(p.origin.GetId()),
+				Originator:   p.sdm.GetMe().ToPB(),
+			}
+			for i, op := range ops {
+				if !origin.members.canSee(visibility[i], as) {
+					if !strings.HasPrefix(op.Op, "create") {
+						continue
+					}
+					// Members that can't see the value still learn about the key
+					op = proto.Clone(op).(*pb.SharedDataOp)
+					op.Value = nil
+				}
+				req.Ops = append(req.Ops, op)
+			}
+			if len(req.Ops) == 0 {
+				return nil
+			}
+			return req
+		}, &resp), nil
+	})
 }
 
 func (p *sharedDataProxy) GetOwner(key string) string {
@@ -274,27 +1097,88 @@ func (p *sharedDataProxy) IsMe(me string) bool {
 	return p.origin.IsMe(me)
 }
 
-func (p *sharedDataProxy) OnDataChangeCB(cb func(key string)) {
-	p.origin.OnDataChangeCB(cb)
+func (p *sharedDataProxy) OnDataChangeCB(cb func(change DataChange)) func() {
+	return p.origin.OnDataChangeCB(cb)
 }
 
-func (p *sharedDataProxy) ChangeDataOwner(key string, owner string) {
-	p.lock.Lock()
-	defer p.lock.Unlock()
+func (p *sharedDataProxy) ChangeDataOwner(key string, owner string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.changeDataOwner(origin.self(), key, owner); err != nil {
+			return nil, err
+		}
 
-	// TODO send to others
-	req := pb.SharedDataChangeOwner{
-		SharedDataId: string(p.origin.GetId()),
-		Originator:   p.sdm.GetMe().ToPB(),
-		Key:          key,
-		Owner:        owner,
-	}
-	resp := pb.SharedDataChangeOwnerResponse{}
-	for key, value := range p.invities {
-		if key != p.GetMe() {
-			p.sdm.clientCache.POST(value.Address, "/shareddata/changeowner", &req, &resp)
+		req := pb.SharedDataChangeOwner{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Owner:        owner,
 		}
-	}
+		resp := pb.SharedDataChangeOwnerResponse{}
+		return p.broadcast("/shareddata/changeowner", "", &req, nil, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) DefineGroup(group string, roles []string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		if err := origin.defineGroup(origin.self(), group, roles); err != nil {
+			return nil, err
+		}
+		p.snapshot()
+
+		req := pb.SharedDataDefineGroup{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Group:        group,
+			Roles:        roles,
+		}
+		resp := pb.SharedDataDefineGroupResponse{}
+		return p.broadcast("/shareddata/definegroup", "", &req, nil, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) GetGroup(group string) []string {
+	return p.origin.GetGroup(group)
+}
+
+func (p *sharedDataProxy) SetVisibility(key string, visibility string) error {
+	return p.change(func() (*delivery, error) {
+		origin := originOf(p)
+		codec := p.sdm.GetCodec()
+		d := origin.data[key]
+		b, err := encode(codec, d.raw())
+		if err != nil {
+			return nil, err
+		}
+		if err := origin.setVisibility(origin.self(), key, visibility, d.raw(), d.version); err != nil {
+			return nil, err
+		}
+
+		req := pb.SharedDataSetVisibility{
+			SharedDataId: string(p.origin.GetId()),
+			Originator:   p.sdm.GetMe().ToPB(),
+			Key:          key,
+			Visibility:   visibility,
+			Value:        b,
+			Codec:        codec.Id(),
+			Version:      d.version,
+		}
+		hidden := proto.Clone(&req).(*pb.SharedDataSetVisibility)
+		hidden.Value = nil
+		resp := pb.SharedDataSetVisibilityResponse{}
+		return p.broadcast("/shareddata/setvisibility", visibility, &req, hidden, &resp), nil
+	})
+}
+
+func (p *sharedDataProxy) SetSchema(schema *Schema) error {
+	return p.origin.SetSchema(schema)
+}
+
+func (p *sharedDataProxy) GetSchema() *Schema {
+	return p.origin.GetSchema()
+}
 
-	p.origin.ChangeDataOwner(key, owner)
+func (p *sharedDataProxy) GetVisibility(key string) string {
+	return p.origin.GetVisibility(key)
 }
@@ -0,0 +1,327 @@
+package shareddata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hoyle1974/grapevine/common"
+	pb "github.com/hoyle1974/grapevine/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// SharedDataStore keeps shared data across restarts, as a snapshot of each
+// share and a log of the records appended since the snapshot was taken.
+type SharedDataStore interface {
+	// Append adds record to the log of id.
+	Append(id SharedDataId, record []byte) error
+	// Snapshot replaces everything kept for id with snapshot.
+	Snapshot(id SharedDataId, snapshot []byte) error
+	// Load returns the snapshot of id and the records appended since.
+	Load(id SharedDataId) ([]byte, [][]byte, error)
+	List() ([]SharedDataId, error)
+	Remove(id SharedDataId) error
+}
+
+// snapshotEvery is how many records are logged before they are folded into
+// a new snapshot.
+const snapshotEvery = 100
+
+const snapshotExt = ".snapshot"
+const logExt = ".log"
+
+type fileStore struct {
+	lock sync.Mutex
+	dir  string
+}
+
+// NewFileStore keeps each share in dir as a snapshot file and an append-only
+// log of length prefixed records.
+func NewFileStore(dir string) (SharedDataStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+func (f *fileStore) path(id SharedDataId, ext string) string {
+	return filepath.Join(f.dir, url.PathEscape(string(id))+ext)
+}
+
+func (f *fileStore) Append(id SharedDataId, record []byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	file, err := os.OpenFile(f.path(id, logExt), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	buf := binary.AppendUvarint(nil, uint64(len(record)))
+	if _, err := file.Write(append(buf, record...)); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+func (f *fileStore) Snapshot(id SharedDataId, snapshot []byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	tmp := f.path(id, snapshotExt+".tmp")
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(snapshot)
+	if err == nil {
+		err = file.Sync()
+	}
+	file.Close()
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, f.path(id, snapshotExt)); err != nil {
+		return err
+	}
+	// Should we stop here the records are replayed over a snapshot that
+	// already has them, which does no harm
+	if err := os.Remove(f.path(id, logExt)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (f *fileStore) Load(id SharedDataId) ([]byte, [][]byte, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	snapshot, err := os.ReadFile(f.path(id, snapshotExt))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = fmt.Errorf("%w: %v", ErrUnknownSharedData, id)
+		}
+		return nil, nil, err
+	}
+
+	b, err := os.ReadFile(f.path(id, logExt))
+	if errors.Is(err, os.ErrNotExist) {
+		return snapshot, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records [][]byte
+	r := bytes.NewReader(b)
+	for {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			break
+		}
+		// A length longer than what is left was cut short by a crash while
+		// it was written, like the record it is for
+		if n > uint64(r.Len()) {
+			break
+		}
+		record := make([]byte, n)
+		if _, err := io.ReadFull(r, record); err != nil {
+			break
+		}
+		records = append(records, record)
+	}
+	return snapshot, records, nil
+}
+
+func (f *fileStore) List() ([]SharedDataId, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []SharedDataId
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), snapshotExt)
+		if !ok {
+			continue
+		}
+		id, err := url.PathUnescape(name)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, SharedDataId(id))
+	}
+	return ids, nil
+}
+
+func (f *fileStore) Remove(id SharedDataId) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, ext := range []string{snapshotExt, logExt} {
+		if err := os.Remove(f.path(id, ext)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordQueue holds what is waiting to be written to the store for a share.
+// Writes are queued with the lock of the share held, so they are in the order
+// the changes were made, and made without it so nobody waits on the disk.
+type recordQueue struct {
+	lock    sync.Mutex
+	pending []func()
+	writing sync.Mutex
+}
+
+func (q *recordQueue) add(write func()) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.pending = append(q.pending, write)
+}
+
+// write makes the writes queued so far, it returns once they are made even
+// if someone else is making them.
+func (q *recordQueue) write() {
+	q.writing.Lock()
+	defer q.writing.Unlock()
+
+	q.lock.Lock()
+	pending := q.pending
+	q.pending = nil
+	q.lock.Unlock()
+	for _, write := range pending {
+		write()
+	}
+}
+
+// persist logs change, which was just made, to the store.  It is called
+// with the lock held, the record is written once it is released.
+func (p *sharedDataProxy) persist(change DataChange) {
+	store := p.sdm.GetStore()
+	if store == nil {
+		return
+	}
+
+	record := &pb.SharedDataStoreRecord{Key: change.Key}
+	if d, ok := originOf(p).data[change.Key]; ok {
+		data, err := encodeData(p.sdm.GetCodec(), d, true)
+		if err != nil {
+			p.sdm.ctx.Warn().Err(err).Msgf("Could not store %v", change.Key)
+			return
+		}
+		record.Data = data
+	} else {
+		record.Deleted = true
+	}
+	if entry, ok := originOf(p).history.last(); ok && entry.Id == opIdOf(change.Key, change.Version) {
+		e, err := encodeHistory(p.sdm.GetCodec(), entry, true)
+		if err != nil {
+			p.sdm.ctx.Warn().Err(err).Msgf("Could not store %v", change.Key)
+			return
+		}
+		record.Entry = e
+	}
+
+	b, err := proto.Marshal(record)
+	if err != nil {
+		p.sdm.ctx.Warn().Err(err).Msgf("Could not store %v", change.Key)
+		return
+	}
+	id := p.origin.GetId()
+	p.records.add(func() {
+		if err := store.Append(id, b); err != nil {
+			p.sdm.ctx.Warn().Err(err).Msgf("Could not store %v", change.Key)
+		}
+	})
+
+	p.logged++
+	if p.logged >= snapshotEvery {
+		p.snapshot()
+	}
+}
+
+// snapshot replaces what the store has for the share with its current
+// state.  It is called with the lock held, like persist.
+func (p *sharedDataProxy) snapshot() {
+	store := p.sdm.GetStore()
+	if store == nil {
+		return
+	}
+
+	state, err := p.getState(p.GetMe())
+	if err != nil {
+		p.sdm.ctx.Warn().Err(err).Msgf("Could not snapshot %v", p.origin.GetId())
+		return
+	}
+	b, err := proto.Marshal(&pb.SharedDataStoreSnapshot{
+		State:   state,
+		Creator: originOf(p).creator.ToPB(),
+		Me:      p.GetMe(),
+	})
+	if err != nil {
+		p.sdm.ctx.Warn().Err(err).Msgf("Could not snapshot %v", p.origin.GetId())
+		return
+	}
+	id := p.origin.GetId()
+	p.records.add(func() {
+		if err := store.Snapshot(id, b); err != nil {
+			p.sdm.ctx.Warn().Err(err).Msgf("Could not snapshot %v", id)
+		}
+	})
+	p.logged = 0
+}
+
+// restore loads the share id from store, as it was when last stored.
+func (sdm *sharedDataManager) restore(store SharedDataStore, id SharedDataId) (SharedDataProxy, error) {
+	b, records, err := store.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &pb.SharedDataStoreSnapshot{}
+	if err := proto.Unmarshal(b, snapshot); err != nil {
+		return nil, err
+	}
+	state := snapshot.State
+	if state == nil {
+		return nil, fmt.Errorf("%w: %v has no state", ErrUnknownSharedData, id)
+	}
+	if state.Data == nil {
+		state.Data = make(map[string]*pb.SharedDataData)
+	}
+	for _, b := range records {
+		record := &pb.SharedDataStoreRecord{}
+		if err := proto.Unmarshal(b, record); err != nil {
+			return nil, err
+		}
+		if record.Deleted {
+			delete(state.Data, record.Key)
+		} else {
+			state.Data[record.Key] = record.Data
+		}
+		if record.Entry != nil {
+			state.History = append(state.History, record.Entry)
+		}
+	}
+
+	sd := NewSharedData(common.NewContactFromPB(snapshot.Creator), id)
+	sd.SetMe(snapshot.Me)
+	proxy := NewSharedDataProxy(sd, sdm)
+	if err := sdm.applyState(proxy, state); err != nil {
+		return nil, err
+	}
+	// We are probably somewhere new
+	proxy.AddInvitee(sdm.GetMe(), snapshot.Me)
+	return proxy, nil
+}
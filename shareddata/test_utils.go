@@ -1,6 +1,8 @@
 package shareddata
 
 import (
+	"sync"
+
 	"github.com/hoyle1974/grapevine/common"
 )
 
@@ -9,6 +11,10 @@ type TestClientCallback struct {
 	sharedData   SharedData
 	sharedDataId SharedDataId
 	me           string
+	lock         sync.Mutex
+	host         string
+	invites      []Invitation
+	declined     map[InviteId]string
 }
 
 func (cb *TestClientCallback) OnSearch(id SearchId, query string) bool {
@@ -19,6 +25,8 @@ func (cb *TestClientCallback) OnSearchResult(id SearchId, result string, contact
 }
 func (cb *TestClientCallback) OnInvited(sharedDataId SharedDataId, me string, contact common.Contact) bool {
 	// fmt.Println("--------- OnInvited: " + cb.name)
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
 	cb.sharedDataId = sharedDataId
 	cb.me = me
 
@@ -28,9 +36,69 @@ func (cb *TestClientCallback) OnInviteAccepted(sharedData SharedData, contact co
 	// fmt.Println("--------- OnInviteAccepted: " + cb.name)
 }
 func (cb *TestClientCallback) OnSharedDataAvailable(sharedData SharedData) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
 	cb.sharedData = sharedData
 }
 
+// SharedData returns the shared data we were last given by
+// OnSharedDataAvailable.
+func (cb *TestClientCallback) SharedData() SharedData {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	return cb.sharedData
+}
+
+// Invited returns the shared data and role we were last invited to.
+func (cb *TestClientCallback) Invited() (SharedDataId, string) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	return cb.sharedDataId, cb.me
+}
+
+func (cb *TestClientCallback) OnInviteReceived(invite Invitation) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	cb.invites = append(cb.invites, invite)
+}
+
+// Invites returns the invitations we were told about by OnInviteReceived.
+func (cb *TestClientCallback) Invites() []Invitation {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	return append([]Invitation(nil), cb.invites...)
+}
+
+func (cb *TestClientCallback) OnInviteDeclined(invite Invitation, reason string) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	if cb.declined == nil {
+		cb.declined = make(map[InviteId]string)
+	}
+	cb.declined[invite.Id] = reason
+}
+
+// Declined returns why invite id was declined, if it was.
+func (cb *TestClientCallback) Declined(id InviteId) (string, bool) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	reason, ok := cb.declined[id]
+	return reason, ok
+}
+
+func (cb *TestClientCallback) OnHostChanged(sharedData SharedData, host string, contact common.Contact) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	cb.host = host
+}
+
+// Host returns the host we were last told about by OnHostChanged.
+func (cb *TestClientCallback) Host() string {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	return cb.host
+}
+
 func NewTestClientCb(name string) *TestClientCallback {
 	return &TestClientCallback{name: name}
 }
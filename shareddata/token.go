@@ -0,0 +1,153 @@
+package shareddata
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hoyle1974/grapevine/client"
+	"github.com/hoyle1974/grapevine/common"
+	pb "github.com/hoyle1974/grapevine/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// A join token lets whoever has it join a shared data as a role without
+// being invited, so it can be handed out as a link.  It is signed with a key
+// only the member that made it knows, so that member has to be around to
+// admit anyone with it, and as the key isn't kept its tokens are no good
+// once it restarts.
+
+func newTokenKey() []byte {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// CreateJoinToken makes a token that JoinWithToken joins s with as the role
+// as, until expiry has passed.  The role goes to the first to use it.
+func (sdm *sharedDataManager) CreateJoinToken(s SharedData, as string, expiry time.Duration) (string, error) {
+	sdm.lock.Lock()
+	defer sdm.lock.Unlock()
+
+	if _, ok := sdm.data[s.GetId()]; !ok {
+		return "", fmt.Errorf("%w: %v", ErrUnknownSharedData, s.GetId())
+	}
+	payload, err := proto.Marshal(&pb.SharedDataJoinToken{
+		SharedDataId: string(s.GetId()),
+		Issuer:       sdm.GetMe().ToPB(),
+		As:           as,
+		Expires:      timestamppb.New(time.Now().Add(expiry)),
+	})
+	if err != nil {
+		return "", err
+	}
+	return encodeToken(payload, sdm.sign(payload)), nil
+}
+
+// JoinWithToken joins the shared data token was made for, by having the
+// member that made it admit us.  The shared data is also handed to
+// OnSharedDataAvailable.
+func (sdm *sharedDataManager) JoinWithToken(token string) (SharedData, error) {
+	claims, _, _, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Expires.AsTime().Before(time.Now()) {
+		return nil, fmt.Errorf("%w: the token for %v", ErrInviteExpired, claims.SharedDataId)
+	}
+
+	sdm.lock.Lock()
+	defer sdm.lock.Unlock()
+
+	issuer := common.NewContactFromPB(claims.Issuer)
+	req := pb.SharedDataRedeem{Token: token, Originator: sdm.GetMe().ToPB()}
+	resp := pb.SharedDataRedeemResponse{}
+	if err := sdm.clientCache.POST(issuer.Address, "/shareddata/redeem", &req, &resp); err != nil {
+		return nil, tokenError(err)
+	}
+
+	return sdm.enter(issuer, SharedDataId(claims.SharedDataId), claims.As, issuer, resp.State, resp.Next)
+}
+
+// redeem checks that token was made by us, is still good and that its role
+// is free for contact.  It is called with the lock held.
+func (sdm *sharedDataManager) redeem(token string, contact common.Contact) (*pb.SharedDataJoinToken, error) {
+	claims, payload, signature, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(signature, sdm.sign(payload)) {
+		return nil, fmt.Errorf("%w: it wasn't made here", ErrInvalidToken)
+	}
+	if claims.Expires.AsTime().Before(time.Now()) {
+		return nil, fmt.Errorf("%w: the token for %v", ErrInviteExpired, claims.SharedDataId)
+	}
+
+	proxy, ok := sdm.data[SharedDataId(claims.SharedDataId)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownSharedData, claims.SharedDataId)
+	}
+	// Whoever has the role may use the token again to come back
+	if current, ok := proxy.GetInvitees()[claims.As]; ok && current.AccountId != contact.AccountId {
+		return nil, fmt.Errorf("%w: %v is already taken", ErrConflict, claims.As)
+	}
+	return claims, nil
+}
+
+func (sdm *sharedDataManager) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, sdm.tokenKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func encodeToken(payload []byte, signature []byte) string {
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// parseToken reads what token says, without checking who signed it.
+func parseToken(token string) (*pb.SharedDataJoinToken, []byte, []byte, error) {
+	p, s, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, nil, nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(p)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	claims := &pb.SharedDataJoinToken{}
+	if err := proto.Unmarshal(payload, claims); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return claims, payload, signature, nil
+}
+
+// tokenError turns the refusal of a token back into why it was refused.
+func tokenError(err error) error {
+	var serr *client.StatusError
+	if errors.As(err, &serr) {
+		switch serr.StatusCode {
+		case http.StatusForbidden:
+			return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: %v", ErrUnknownSharedData, err)
+		case http.StatusConflict:
+			return fmt.Errorf("%w: the role is already taken", ErrConflict)
+		case http.StatusGone:
+			return fmt.Errorf("%w: %v", ErrInviteExpired, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,212 @@
+package shareddata
+
+import (
+	"fmt"
+)
+
+// Tx is how a function passed to Transaction changes the SharedData.  Reads
+// see the changes already made, which are only kept, and sent to the other
+// members, if every one of them is allowed and the function returns nil.
+type Tx interface {
+	Get(key string) interface{}
+	GetOwner(key string) string
+	Create(key string, value interface{}, owner string, visibility string) error
+	CreateArray(key string, value []interface{}, owner string, visibility string) error
+	CreateMap(key string, value interface{}, owner string, visibility string) error
+	Set(key string, value interface{}) error
+	SetMap(key string, mapKey string, value interface{}) error
+	Append(key string, value interface{}) error
+	ChangeDataOwner(key string, owner string) error
+	Increment(key string, delta int64) error
+	AddToSet(key string, value interface{}) error
+	RemoveFromSet(key string, value interface{}) error
+}
+
+// txOp is a change made in a transaction, op is named like the route the
+// change would otherwise be sent to.
+type txOp struct {
+	op         string
+	key        string
+	mapKey     string
+	value      interface{}
+	owner      string
+	visibility string
+}
+
+type transaction struct {
+	s          *sharedData
+	originator member
+	ops        []txOp
+	err        error
+}
+
+// do makes a change and records it, once a change fails the transaction
+// can't succeed so the rest are refused.
+func (tx *transaction) do(op txOp, change func() error) error {
+	if tx.err != nil {
+		return tx.err
+	}
+	if err := change(); err != nil {
+		tx.err = err
+		return err
+	}
+	tx.ops = append(tx.ops, op)
+	return nil
+}
+
+func (tx *transaction) Get(key string) interface{} {
+	return copyValue(tx.s.data[key].get())
+}
+
+func (tx *transaction) GetOwner(key string) string {
+	return tx.s.data[key].owner
+}
+
+func (tx *transaction) Create(key string, value interface{}, owner string, visibility string) error {
+	return tx.do(txOp{op: "create", key: key, value: value, owner: owner, visibility: visibility}, func() error {
+		return tx.s.create(tx.originator, key, value, owner, visibility)
+	})
+}
+
+func (tx *transaction) CreateArray(key string, value []interface{}, owner string, visibility string) error {
+	return tx.do(txOp{op: "createarray", key: key, value: value, owner: owner, visibility: visibility}, func() error {
+		return tx.s.createArray(tx.originator, key, value, owner, visibility)
+	})
+}
+
+func (tx *transaction) CreateMap(key string, value interface{}, owner string, visibility string) error {
+	return tx.do(txOp{op: "createmap", key: key, value: value, owner: owner, visibility: visibility}, func() error {
+		return tx.s.createMap(tx.originator, key, value, owner, visibility)
+	})
+}
+
+func (tx *transaction) Set(key string, value interface{}) error {
+	return tx.do(txOp{op: "set", key: key, value: value}, func() error {
+		return tx.s.set(tx.originator, key, value)
+	})
+}
+
+func (tx *transaction) SetMap(key string, mapKey string, value interface{}) error {
+	return tx.do(txOp{op: "setmap", key: key, mapKey: mapKey, value: value}, func() error {
+		return tx.s.setMap(tx.originator, key, mapKey, value)
+	})
+}
+
+func (tx *transaction) Append(key string, value interface{}) error {
+	return tx.do(txOp{op: "append", key: key, value: value}, func() error {
+		return tx.s.appendValue(tx.originator, key, value)
+	})
+}
+
+func (tx *transaction) ChangeDataOwner(key string, owner string) error {
+	return tx.do(txOp{op: "changeowner", key: key, owner: owner}, func() error {
+		return tx.s.changeDataOwner(tx.originator, key, owner)
+	})
+}
+
+func (tx *transaction) Increment(key string, delta int64) error {
+	return tx.do(txOp{op: "increment", key: key}, func() error {
+		return tx.s.increment(tx.originator, key, delta)
+	})
+}
+
+func (tx *transaction) AddToSet(key string, value interface{}) error {
+	return tx.do(txOp{op: "addtoset", key: key, value: value}, func() error {
+		return tx.s.addToSet(tx.originator, key, value)
+	})
+}
+
+func (tx *transaction) RemoveFromSet(key string, value interface{}) error {
+	return tx.do(txOp{op: "removefromset", key: key, value: value}, func() error {
+		return tx.s.removeFromSet(tx.originator, key, value)
+	})
+}
+
+// apply makes a change received from another member.  Changes to crdts
+// arrive as a "merge" of the sender's copy.
+func (tx *transaction) apply(op txOp) error {
+	if c, ok := op.value.(crdt); ok && op.op != "create" && op.op != "merge" {
+		return fmt.Errorf("%w: %v can't be given a %v", ErrWrongType, op.key, c.kind())
+	}
+	switch op.op {
+	case "create":
+		return tx.Create(op.key, op.value, op.owner, op.visibility)
+	case "createarray":
+		avalue, ok := op.value.([]interface{})
+		if !ok && op.value != nil {
+			return fmt.Errorf("%w: %v is a %T, not an array", ErrWrongType, op.key, op.value)
+		}
+		return tx.CreateArray(op.key, avalue, op.owner, op.visibility)
+	case "createmap":
+		if op.value == nil {
+			// A map we aren't allowed to see
+			return tx.Create(op.key, nil, op.owner, op.visibility)
+		}
+		return tx.CreateMap(op.key, op.value, op.owner, op.visibility)
+	case "set":
+		return tx.Set(op.key, op.value)
+	case "setmap":
+		return tx.SetMap(op.key, op.mapKey, op.value)
+	case "append":
+		return tx.Append(op.key, op.value)
+	case "changeowner":
+		return tx.ChangeDataOwner(op.key, op.owner)
+	case "merge":
+		other, ok := op.value.(crdt)
+		if !ok {
+			return fmt.Errorf("%w: merging a %T into %v", ErrWrongType, op.value, op.key)
+		}
+		return tx.do(op, func() error {
+			return tx.s.merge(tx.originator, op.key, other)
+		})
+	}
+	return fmt.Errorf("%w: unknown transaction op %v", ErrWrongType, op.op)
+}
+
+// Transaction makes the changes f makes through tx as one.  If any of them
+// isn't allowed, or f returns an error, none of them are made.  f is called
+// with the lock held, so it must only use tx.
+func (s *sharedData) Transaction(f func(tx Tx) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.transaction(s.self(), func(tx *transaction) error { return f(tx) }, nil)
+}
+
+// transaction runs f and keeps the changes it made as originator if it, and
+// then prepare, succeed.  Listeners only hear about changes that are kept.
+func (s *sharedData) transaction(originator member, f func(tx *transaction) error, prepare func(ops []txOp) error) error {
+	saved := s.snapshot()
+	s.listeners.hold()
+
+	tx := &transaction{s: s, originator: originator}
+	err := f(tx)
+	if err == nil {
+		err = tx.err
+	}
+	if err == nil && prepare != nil {
+		err = prepare(tx.ops)
+	}
+	if err != nil {
+		s.data = saved
+		s.listeners.drop()
+		return err
+	}
+	s.listeners.release()
+	return nil
+}
+
+// snapshot copies everything a transaction could change in place, it is
+// also what GetData hands out.
+func (s *sharedData) snapshot() map[string]data {
+	saved := make(map[string]data, len(s.data))
+	for key, d := range s.data {
+		if c, ok := d.value.(crdt); ok {
+			d.value = ownCopy(c)
+		} else {
+			d.value = copyValue(d.value)
+		}
+		d.avalue, _ = copyValue(d.avalue).([]interface{})
+		saved[key] = d
+	}
+	return saved
+}
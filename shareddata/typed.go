@@ -0,0 +1,321 @@
+package shareddata
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Values arrive from other members as whatever their codec decoded, so
+// reading one means a type assertion that panics if it isn't what was
+// expected.  Key, ArrayKey and MapKey name a key along with the type of its
+// value.  They declare that type on the shared data, which then refuses
+// changes to the key, local or remote, that don't fit it with ErrWrongType,
+// and they read the value back as that type.
+
+// Register is RegisterType for values of type T, which can't be an
+// interface.
+func Register[T any]() {
+	var value T
+	RegisterType(value)
+}
+
+// Get returns the value of key as a T.  A value of a compatible type, such
+// as an int64 for an int or []interface{} for a []string, is converted.  A
+// value we can't see is the zero value.
+func Get[T any](sd SharedData, key string) (T, error) {
+	var value T
+	v := sd.Get(key)
+	if isNil(v) {
+		if sd.GetOwner(key) == "" {
+			return value, fmt.Errorf("%w: %v", ErrUnknownKey, key)
+		}
+		return value, nil
+	}
+	rv, err := convert(TypeOf[T](), v)
+	if err != nil {
+		return value, fmt.Errorf("%v: %w", key, err)
+	}
+	return rv.Interface().(T), nil
+}
+
+// isNil is true for nil, and for the nil []interface{} of an array key that
+// isn't there or that we can't see.
+func isNil(v interface{}) bool {
+	a, ok := v.([]interface{})
+	return v == nil || (ok && a == nil)
+}
+
+// Key is a key whose value is a T.
+type Key[T any] string
+
+// Declare has sd refuse values of key that aren't a T.  It returns
+// ErrWrongType if the key already holds something else.
+func (k Key[T]) Declare(sd SharedData) error {
+	return declare(sd, string(k), TypeOf[T]())
+}
+
+func (k Key[T]) Create(sd SharedData, value T, owner string, visibility string) error {
+	if err := k.Declare(sd); err != nil {
+		return err
+	}
+	return sd.Create(string(k), value, owner, visibility)
+}
+
+func (k Key[T]) Get(sd SharedData) (T, error) {
+	return Get[T](sd, string(k))
+}
+
+func (k Key[T]) Set(sd SharedData, value T) error {
+	return sd.Set(string(k), value)
+}
+
+// ArrayKey is a key whose value is an array of E.
+type ArrayKey[E any] string
+
+// Declare has sd refuse values of key that aren't an array of E.  It
+// returns ErrWrongType if the key already holds something else.
+func (k ArrayKey[E]) Declare(sd SharedData) error {
+	return declare(sd, string(k), TypeOf[[]E]())
+}
+
+func (k ArrayKey[E]) Create(sd SharedData, values []E, owner string, visibility string) error {
+	if err := k.Declare(sd); err != nil {
+		return err
+	}
+	avalue := make([]interface{}, len(values))
+	for i, v := range values {
+		avalue[i] = v
+	}
+	return sd.CreateArray(string(k), avalue, owner, visibility)
+}
+
+func (k ArrayKey[E]) Get(sd SharedData) ([]E, error) {
+	return Get[[]E](sd, string(k))
+}
+
+// At returns the element at index.
+func (k ArrayKey[E]) At(sd SharedData, index int) (E, error) {
+	var value E
+	values, err := k.Get(sd)
+	if err != nil {
+		return value, err
+	}
+	if index < 0 || index >= len(values) {
+		return value, fmt.Errorf("%w: %v %d of %d", ErrOutOfRange, string(k), index, len(values))
+	}
+	return values[index], nil
+}
+
+func (k ArrayKey[E]) Len(sd SharedData) int {
+	return sd.Len(string(k))
+}
+
+func (k ArrayKey[E]) Append(sd SharedData, value E) error {
+	return sd.Append(string(k), value)
+}
+
+func (k ArrayKey[E]) InsertAt(sd SharedData, index int, value E) error {
+	return sd.InsertAt(string(k), index, value)
+}
+
+func (k ArrayKey[E]) RemoveAt(sd SharedData, index int) error {
+	return sd.RemoveAt(string(k), index)
+}
+
+// MapKey is a key whose value is a map of E.
+type MapKey[E any] string
+
+// Declare has sd refuse values of key that aren't a map of E.  It returns
+// ErrWrongType if the key already holds something else.
+func (k MapKey[E]) Declare(sd SharedData) error {
+	return declare(sd, string(k), TypeOf[map[string]E]())
+}
+
+func (k MapKey[E]) Create(sd SharedData, values map[string]E, owner string, visibility string) error {
+	if err := k.Declare(sd); err != nil {
+		return err
+	}
+	return sd.CreateMap(string(k), values, owner, visibility)
+}
+
+func (k MapKey[E]) Get(sd SharedData) (map[string]E, error) {
+	return Get[map[string]E](sd, string(k))
+}
+
+// Lookup returns the value of mapKey, and false if there isn't one.
+func (k MapKey[E]) Lookup(sd SharedData, mapKey string) (E, bool, error) {
+	values, err := k.Get(sd)
+	value, ok := values[mapKey]
+	return value, ok, err
+}
+
+func (k MapKey[E]) Len(sd SharedData) int {
+	return sd.Len(string(k))
+}
+
+func (k MapKey[E]) Set(sd SharedData, mapKey string, value E) error {
+	return sd.SetMap(string(k), mapKey, value)
+}
+
+func (k MapKey[E]) Delete(sd SharedData, mapKey string) error {
+	return sd.DeleteMapKey(string(k), mapKey)
+}
+
+// declare makes t the type of the value of key in sd.
+func declare(sd SharedData, key string, t reflect.Type) error {
+	var s *sharedData
+	switch v := sd.(type) {
+	case *sharedData:
+		s = v
+	case SharedDataProxy:
+		s = originOf(v)
+	default:
+		return fmt.Errorf("%w: types can't be declared on a %T", ErrWrongType, sd)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if d, ok := s.data[key]; ok {
+		if v := d.raw(); v != nil {
+			if _, err := conform(t, v); err != nil {
+				return fmt.Errorf("%v: %w", key, err)
+			}
+		}
+	}
+	if s.types == nil {
+		s.types = make(map[string]reflect.Type)
+	}
+	s.types[key] = t
+	return nil
+}
+
+// conform checks value fits the type declared for key, if there is one, and
+// the schema, and returns it converted to that type.  It is called with the
+// lock held.
+func (s *sharedData) conform(key string, value interface{}) (interface{}, error) {
+	if err := s.schema.allows(key); err != nil {
+		return nil, err
+	}
+	if t, ok := s.types[key]; ok && value != nil {
+		v, err := conform(t, value)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", key, err)
+		}
+		value = v
+	}
+	if err := s.schema.validate(key, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// conformElem is conform for an element of the array or map key.
+func (s *sharedData) conformElem(key string, value interface{}) (interface{}, error) {
+	if t, ok := s.types[key]; ok && (t.Kind() == reflect.Slice || t.Kind() == reflect.Map) {
+		v, err := conform(t.Elem(), value)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", key, err)
+		}
+		value = v
+	}
+	if err := s.schema.validateElem(key, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// conform is convert for values kept in a shared data, arrays and maps stay
+// []interface{} and map[string]interface{} so they can still be changed an
+// element at a time.
+func conform(t reflect.Type, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		if t.Kind() == reflect.Slice {
+			out := make([]interface{}, len(v))
+			for i, e := range v {
+				c, err := conform(t.Elem(), e)
+				if err != nil {
+					return nil, err
+				}
+				out[i] = c
+			}
+			return out, nil
+		}
+	case map[string]interface{}:
+		if t.Kind() == reflect.Map && t.Key().Kind() == reflect.String {
+			out := make(map[string]interface{}, len(v))
+			for k, e := range v {
+				c, err := conform(t.Elem(), e)
+				if err != nil {
+					return nil, err
+				}
+				out[k] = c
+			}
+			return out, nil
+		}
+	}
+	rv, err := convert(t, value)
+	if err != nil {
+		return nil, err
+	}
+	return rv.Interface(), nil
+}
+
+// convert turns value into a t, if it is one or can be made one without
+// losing anything: numbers of another kind that hold the same number, and
+// arrays and maps of things that convert.
+func convert(t reflect.Type, value interface{}) (reflect.Value, error) {
+	if value == nil {
+		switch t.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice:
+			return reflect.Zero(t), nil
+		}
+		return reflect.Value{}, fmt.Errorf("%w: nil is not a %v", ErrWrongType, t)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(t) {
+		out := reflect.New(t).Elem()
+		out.Set(rv)
+		return out, nil
+	}
+	switch {
+	case isNumber(rv.Kind()) && isNumber(t.Kind()):
+		out := rv.Convert(t)
+		if out.Convert(rv.Type()).Interface() == value {
+			return out, nil
+		}
+	case rv.Kind() == reflect.Slice && t.Kind() == reflect.Slice:
+		out := reflect.MakeSlice(t, rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			e, err := convert(t.Elem(), rv.Index(i).Interface())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Index(i).Set(e)
+		}
+		return out, nil
+	case rv.Kind() == reflect.Map && t.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String && t.Key().Kind() == reflect.String:
+		out := reflect.MakeMapWithSize(t, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			e, err := convert(t.Elem(), iter.Value().Interface())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.SetMapIndex(iter.Key().Convert(t.Key()), e)
+		}
+		return out, nil
+	}
+	return reflect.Value{}, fmt.Errorf("%w: a %T is not a %v", ErrWrongType, value, t)
+}
+
+func isNumber(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}